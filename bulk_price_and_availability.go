@@ -0,0 +1,228 @@
+package ingram
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type BulkPriceAndAvailabilityOptions struct {
+	// CustomerNumber and ISOCountryCode default to the client's WithCustomerNumber and WithCountryCode.
+	CustomerNumber string `validate:"required"`
+	ISOCountryCode string `validate:"required"`
+	// BatchSize is the number of products looked up per request. It defaults to MaxPriceAndAvailabilityProducts.
+	BatchSize int `validate:"min=0,max=50"`
+}
+
+type bulkPriceAndAvailabilityRow struct {
+	product  Product
+	response *PriceAndAvailabilityResponse
+	err      string
+}
+
+// BulkPriceAndAvailability reads a CSV of part numbers from r, looks them up in batches and writes
+// the prices and availability as CSV to w.
+//
+// The input needs a header row with an "ingramPartNumber" or "vendorPartNumber" column (case-insensitive);
+// rows may use either. The output has one row per input row with customer price, retail price, MAP,
+// currency, total availability, a column per warehouse and an error column. Failed lookups are reported
+// in the error column instead of aborting.
+func (i *Ingram) BulkPriceAndAvailability(ctx context.Context, r io.Reader, w io.Writer, options BulkPriceAndAvailabilityOptions) error {
+	if options.CustomerNumber == "" {
+		options.CustomerNumber = i.customerNumber
+	}
+	if options.ISOCountryCode == "" {
+		options.ISOCountryCode = i.countryCode
+	}
+	err := i.validate.Struct(options)
+	if err != nil {
+		return err
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = MaxPriceAndAvailabilityProducts
+	}
+
+	rows, err := readBulkPriceAndAvailabilityRows(r)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(rows); start += options.BatchSize {
+		end := start + options.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		batch := rows[start:end]
+
+		products := make([]Product, len(batch))
+		for n, row := range batch {
+			products[n] = row.product
+		}
+
+		response, err := i.PriceAndAvailabilityBatch(ctx, &PriceAndAvailabilityBatchRequest{
+			IncludeAvailability: true,
+			IncludePricing:      true,
+			CustomerNumber:      options.CustomerNumber,
+			ISOCountryCode:      options.ISOCountryCode,
+			Products:            products,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			for _, row := range batch {
+				row.err = err.Error()
+			}
+			continue
+		}
+
+		matchBulkPriceAndAvailabilityResponses(batch, response)
+	}
+
+	return writeBulkPriceAndAvailabilityRows(w, rows)
+}
+
+func readBulkPriceAndAvailabilityRows(r io.Reader) ([]*bulkPriceAndAvailabilityRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing csv header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ingramColumn, vendorColumn := -1, -1
+	for n, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "ingrampartnumber":
+			ingramColumn = n
+		case "vendorpartnumber":
+			vendorColumn = n
+		}
+	}
+	if ingramColumn == -1 && vendorColumn == -1 {
+		return nil, errors.New("csv header needs an ingramPartNumber or vendorPartNumber column")
+	}
+
+	var rows []*bulkPriceAndAvailabilityRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := &bulkPriceAndAvailabilityRow{}
+		if ingramColumn != -1 && ingramColumn < len(record) {
+			row.product.IngramPartNumber = strings.TrimSpace(record[ingramColumn])
+		}
+		if vendorColumn != -1 && vendorColumn < len(record) {
+			row.product.VendorPartNumber = strings.TrimSpace(record[vendorColumn])
+		}
+		if row.product.IngramPartNumber == "" && row.product.VendorPartNumber == "" {
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// matchBulkPriceAndAvailabilityResponses assigns the responses to the rows of a batch by part number.
+func matchBulkPriceAndAvailabilityResponses(batch []*bulkPriceAndAvailabilityRow, responses []PriceAndAvailabilityResponse) {
+	for _, row := range batch {
+		for n := range responses {
+			response := &responses[n]
+			if (row.product.IngramPartNumber != "" && strings.EqualFold(row.product.IngramPartNumber, response.IngramPartNumber)) ||
+				(row.product.IngramPartNumber == "" && strings.EqualFold(row.product.VendorPartNumber, response.VendorPartNumber)) {
+				row.response = response
+				break
+			}
+		}
+
+		switch {
+		case row.response == nil:
+			row.err = "product missing in response"
+		case row.response.ProductStatusCode == "E":
+			row.err = row.response.ProductStatusMessage
+			if row.err == "" {
+				row.err = "product error"
+			}
+		}
+	}
+}
+
+func writeBulkPriceAndAvailabilityRows(w io.Writer, rows []*bulkPriceAndAvailabilityRow) error {
+	warehouseSet := make(map[string]bool)
+	for _, row := range rows {
+		if row.response == nil {
+			continue
+		}
+		for _, warehouse := range row.response.Availability.AvailabilityByWarehouse {
+			warehouseSet[warehouse.WarehouseId] = true
+		}
+	}
+	warehouses := make([]string, 0, len(warehouseSet))
+	for id := range warehouseSet {
+		warehouses = append(warehouses, id)
+	}
+	sort.Strings(warehouses)
+
+	cw := csv.NewWriter(w)
+	header := []string{"ingramPartNumber", "vendorPartNumber", "description", "customerPrice", "retailPrice", "mapPrice", "currency", "totalAvailability"}
+	for _, id := range warehouses {
+		header = append(header, fmt.Sprintf("warehouse %s", id))
+	}
+	header = append(header, "error")
+	err := cw.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(header))
+		record[0] = row.product.IngramPartNumber
+		record[1] = row.product.VendorPartNumber
+		record[len(record)-1] = row.err
+
+		if row.response != nil && row.err == "" {
+			p := row.response
+			if p.IngramPartNumber != "" {
+				record[0] = p.IngramPartNumber
+			}
+			if p.VendorPartNumber != "" {
+				record[1] = p.VendorPartNumber
+			}
+			record[2] = p.Description
+			record[3] = p.Pricing.CustomerPrice.String()
+			record[4] = p.Pricing.RetailPrice.String()
+			record[5] = p.Pricing.MapPrice.String()
+			record[6] = p.Pricing.CurrencyCode
+			record[7] = strconv.FormatInt(p.Availability.TotalAvailability, 10)
+			for _, warehouse := range p.Availability.AvailabilityByWarehouse {
+				n := sort.SearchStrings(warehouses, warehouse.WarehouseId)
+				record[8+n] = strconv.FormatInt(warehouse.QuantityAvailable, 10)
+			}
+		}
+
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,158 @@
+package ingram_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+// bulkPriceAndAvailability runs BulkPriceAndAvailability on input and returns the output rows by Ingram part number.
+func bulkPriceAndAvailability(t *testing.T, client *ingram.Ingram, input string, batchSize int) ([]string, map[string]map[string]string) {
+	t.Helper()
+
+	var out bytes.Buffer
+	err := client.BulkPriceAndAvailability(context.Background(), strings.NewReader(input), &out, ingram.BulkPriceAndAvailabilityOptions{BatchSize: batchSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := records[0]
+	rows := make(map[string]map[string]string)
+	for _, record := range records[1:] {
+		row := make(map[string]string)
+		for n, column := range header {
+			row[column] = record[n]
+		}
+		rows[row["ingramPartNumber"]] = row
+	}
+	return header, rows
+}
+
+func newBulkTestServer() *ingramtest.Server {
+	srv := ingramtest.NewServer()
+	srv.AddProduct(
+		ingram.PriceAndAvailabilityResponse{
+			IngramPartNumber: "SKU1",
+			VendorPartNumber: "VPN1",
+			Description:      "Laptop",
+			Pricing:          ingram.Pricing{CurrencyCode: "EUR", CustomerPrice: ingram.MustParseDecimal("19.99")},
+			Availability: ingram.Availability{
+				TotalAvailability: 7,
+				AvailabilityByWarehouse: []ingram.AvailabilityByWarehouse{
+					{WarehouseId: "W2", QuantityAvailable: 5},
+					{WarehouseId: "W1", QuantityAvailable: 2},
+				},
+			},
+		},
+		ingram.PriceAndAvailabilityResponse{
+			IngramPartNumber: "SKU2",
+			VendorPartNumber: "VPN2",
+			Availability: ingram.Availability{
+				TotalAvailability:       3,
+				AvailabilityByWarehouse: []ingram.AvailabilityByWarehouse{{WarehouseId: "W3", QuantityAvailable: 3}},
+			},
+		},
+		ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU3"},
+	)
+	return srv
+}
+
+func TestBulkPriceAndAvailability(t *testing.T) {
+	srv := newBulkTestServer()
+	defer srv.Close()
+	// Reversing the responses makes sure they are matched to the rows by part number, not by position.
+	client := newTestClient(t, srv, ingram.WithMiddleware(ingram.AfterResponse(func(_ context.Context, call *ingram.Call, _ error) {
+		if responses, ok := call.Output.(*[]ingram.PriceAndAvailabilityResponse); ok {
+			r := *responses
+			for a, b := 0, len(r)-1; a < b; a, b = a+1, b-1 {
+				r[a], r[b] = r[b], r[a]
+			}
+		}
+	})))
+
+	header, rows := bulkPriceAndAvailability(t, client, "ingramPartNumber,vendorPartNumber\nSKU1,\n,VPN2\nSKU3,\nUNKNOWN,\n", 2)
+
+	if n := srv.Requests(ingramtest.PriceAndAvailabilityEndpoint); n != 2 {
+		t.Errorf("sent %d requests for 4 rows in batches of 2, want 2", n)
+	}
+	wantHeader := "ingramPartNumber,vendorPartNumber,description,customerPrice,retailPrice,mapPrice,currency,totalAvailability,warehouse W1,warehouse W2,warehouse W3,error"
+	if got := strings.Join(header, ","); got != wantHeader {
+		t.Errorf("header = %s, want %s", got, wantHeader)
+	}
+
+	tests := []struct {
+		part   string
+		column string
+		want   string
+	}{
+		{"SKU1", "description", "Laptop"},
+		{"SKU1", "customerPrice", "19.99"},
+		{"SKU1", "currency", "EUR"},
+		{"SKU1", "totalAvailability", "7"},
+		{"SKU1", "warehouse W1", "2"},
+		{"SKU1", "warehouse W2", "5"},
+		{"SKU1", "warehouse W3", ""},
+		{"SKU1", "error", ""},
+		// The vendor part number row is completed with the Ingram part number of the response.
+		{"SKU2", "vendorPartNumber", "VPN2"},
+		{"SKU2", "warehouse W3", "3"},
+		{"SKU3", "error", ""},
+		{"UNKNOWN", "error", "Invalid SKU"},
+		{"UNKNOWN", "customerPrice", ""},
+	}
+	for _, tt := range tests {
+		row, ok := rows[tt.part]
+		if !ok {
+			t.Errorf("no row for %s", tt.part)
+			continue
+		}
+		if row[tt.column] != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.part, tt.column, row[tt.column], tt.want)
+		}
+	}
+}
+
+func TestBulkPriceAndAvailabilityMissingResponse(t *testing.T) {
+	srv := newBulkTestServer()
+	defer srv.Close()
+	client := newTestClient(t, srv, ingram.WithMiddleware(ingram.AfterResponse(func(_ context.Context, call *ingram.Call, _ error) {
+		if responses, ok := call.Output.(*[]ingram.PriceAndAvailabilityResponse); ok {
+			*responses = (*responses)[:1]
+		}
+	})))
+
+	_, rows := bulkPriceAndAvailability(t, client, "ingramPartNumber\nSKU1\nSKU2\n", 0)
+	if rows["SKU1"]["error"] != "" {
+		t.Errorf("SKU1 error = %q", rows["SKU1"]["error"])
+	}
+	if rows["SKU2"]["error"] != "product missing in response" {
+		t.Errorf("SKU2 error = %q, want product missing in response", rows["SKU2"]["error"])
+	}
+}
+
+func TestBulkPriceAndAvailabilityFailedBatch(t *testing.T) {
+	srv := newBulkTestServer()
+	defer srv.Close()
+	srv.InjectFault(ingramtest.PriceAndAvailabilityEndpoint, ingramtest.Fault{StatusCode: http.StatusBadRequest})
+	client := newTestClient(t, srv)
+
+	_, rows := bulkPriceAndAvailability(t, client, "ingramPartNumber\nSKU1\nSKU2\nSKU3\n", 2)
+	for _, part := range []string{"SKU1", "SKU2"} {
+		if rows[part]["error"] == "" {
+			t.Errorf("%s of the failed batch has no error", part)
+		}
+	}
+	if rows["SKU3"]["error"] != "" {
+		t.Errorf("SKU3 error = %q, want the second batch to succeed", rows["SKU3"]["error"])
+	}
+}
@@ -0,0 +1,110 @@
+package ingram
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+)
+
+// CallOption configures a single API call.
+type CallOption func(o *callOptions)
+
+type callOptions struct {
+	correlationID         string
+	responseCorrelationID *string
+	rawResponse           *[]byte
+	customerNumber        string
+	countryCode           string
+	senderID              string
+}
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a context sending id as IM-CorrelationID with every call made with it.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID set by ContextWithCorrelationID.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// CorrelationID sends id as IM-CorrelationID, taking precedence over the context. Without
+// either, a random ID is generated per call.
+func CorrelationID(id string) CallOption {
+	return func(o *callOptions) {
+		o.correlationID = id
+	}
+}
+
+// CaptureCorrelationID stores the IM-CorrelationID echoed by Ingram in id.
+func CaptureCorrelationID(id *string) CallOption {
+	return func(o *callOptions) {
+		o.responseCorrelationID = id
+	}
+}
+
+// RawResponse stores the undecoded response body in body, also for error responses. Use it to read
+// fields the response types don't map yet, see the Extra fields.
+func RawResponse(body *[]byte) CallOption {
+	return func(o *callOptions) {
+		o.rawResponse = body
+	}
+}
+
+// CustomerNumber sets the customer number for requests leaving it empty, overriding WithCustomerNumber.
+func CustomerNumber(customerNumber string) CallOption {
+	return func(o *callOptions) {
+		o.customerNumber = customerNumber
+	}
+}
+
+// CountryCode sets the ISO country code for requests leaving it empty, overriding WithCountryCode.
+func CountryCode(countryCode string) CallOption {
+	return func(o *callOptions) {
+		o.countryCode = countryCode
+	}
+}
+
+// SenderID sends id as IM-SenderID, overriding WithSenderID.
+func SenderID(id string) CallOption {
+	return func(o *callOptions) {
+		o.senderID = id
+	}
+}
+
+func (i *Ingram) newCallOptions(ctx context.Context, opts []CallOption) *callOptions {
+	o := &callOptions{
+		customerNumber: i.customerNumber,
+		countryCode:    i.countryCode,
+		senderID:       i.senderID,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.correlationID == "" {
+		o.correlationID, _ = CorrelationIDFromContext(ctx)
+	}
+	if o.correlationID == "" {
+		o.correlationID = uuid.NewString()
+	}
+
+	return o
+}
+
+// customer fills an empty customer number and country code of a request from the options. Values set
+// in the request take precedence and are used for the headers of the call.
+func (o *callOptions) customer(customerNumber, countryCode *string) {
+	if *customerNumber == "" {
+		*customerNumber = o.customerNumber
+	}
+	o.customerNumber = *customerNumber
+
+	if *countryCode == "" {
+		*countryCode = o.countryCode
+	}
+	o.countryCode = *countryCode
+}
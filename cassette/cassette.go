@@ -0,0 +1,258 @@
+// Package cassette records HTTP exchanges with the Ingram API into files and replays them,
+// so response parsing can be tested against real payloads without network access.
+//
+// Bearer tokens, client secrets, webhook secret keys and customer numbers are redacted before anything is written.
+//
+//	rec, err := cassette.New("testdata/pa.json", cassette.ModeReplay)
+//	client, err := ingram.New(ingram.WithHTTPClient(rec.Client()), ...)
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/enthus-golang/ingram/internal/redact"
+)
+
+// Redacted replaces secret values in recorded exchanges.
+const Redacted = redact.Placeholder
+
+// Mode controls whether a Recorder talks to the network.
+type Mode int
+
+const (
+	// ModeReplay serves interactions from the cassette and fails on unknown requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends all requests to the network and records them, replacing the cassette on Save.
+	ModeRecord
+	// ModeReplayOrRecord replays known requests and records unknown ones.
+	ModeReplayOrRecord
+)
+
+// ErrInteractionNotFound is returned in ModeReplay for requests not present in the cassette.
+var ErrInteractionNotFound = errors.New("cassette: interaction not found")
+
+// redactedKeys lists the query parameters, form fields and JSON keys holding secrets, the same the
+// client masks in its logs plus customer numbers.
+var redactedKeys = redact.NewFields(append([]string{"customernumber"}, redact.Secrets...)...)
+
+var redactedHeaders = []string{"Authorization", "IM-CustomerNumber"}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is a http.RoundTripper recording to and replaying from a cassette file.
+type Recorder struct {
+	// Transport sends requests in ModeRecord and ModeReplayOrRecord. It defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New loads the cassette at path. The file may only be missing in ModeRecord and ModeReplayOrRecord.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		Transport: http.DefaultTransport,
+		path:      path,
+		mode:      mode,
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) && mode == ModeReplayOrRecord {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, &r.interactions)
+	if err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.interactions))
+
+	return r, nil
+}
+
+// Client returns a http.Client using r as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := newRequest(req, body)
+
+	if r.mode != ModeRecord {
+		if i, ok := r.find(recorded); ok {
+			return i.Response.httpResponse(req), nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, recorded.Method, recorded.Path)
+		}
+	}
+
+	res, err := r.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	header := res.Header.Clone()
+	header.Del("Content-Length")
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       redactedKeys.Body(res.Header.Get("Content-Type"), resBody),
+		},
+	})
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// Save writes all interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, b, 0o644)
+}
+
+// find returns the first unused interaction matching req, falling back to used ones so repeated
+// calls like token requests keep working.
+func (r *Recorder) find(req Request) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fallback := -1
+	for n, i := range r.interactions {
+		if !i.Request.matches(req) {
+			continue
+		}
+		if !r.used[n] {
+			r.used[n] = true
+			return i, true
+		}
+		if fallback == -1 {
+			fallback = n
+		}
+	}
+	if fallback != -1 {
+		return r.interactions[fallback], true
+	}
+
+	return Interaction{}, false
+}
+
+func newRequest(req *http.Request, body []byte) Request {
+	header := req.Header.Clone()
+	for _, h := range redactedHeaders {
+		if header.Get(h) != "" {
+			header.Set(h, Redacted)
+		}
+	}
+
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  redactedKeys.Values(req.URL.Query()).Encode(),
+		Header: header,
+		Body:   redactedKeys.Body(req.Header.Get("Content-Type"), body),
+	}
+}
+
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method &&
+		r.Path == other.Path &&
+		r.Query == other.Query &&
+		canonicalBody(r.Body) == canonicalBody(other.Body)
+}
+
+func (r Response) httpResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// canonicalBody normalizes JSON bodies, so key order and whitespace don't affect matching.
+func canonicalBody(body string) string {
+	v, ok := redact.DecodeJSON([]byte(body))
+	if !ok {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
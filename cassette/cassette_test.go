@@ -0,0 +1,107 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/cassette"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+func TestReplay(t *testing.T) {
+	rec, err := cassette.New("testdata/replay.json", cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Credentials and customer numbers are redacted in the cassette, so any match.
+	client, err := ingram.New(
+		ingram.WithHTTPClient(rec.Client()),
+		ingram.WithBaseURL("https://ingram.invalid"),
+		ingram.WithOAuthCredentials("other-client", "other-secret"),
+		ingram.WithCustomerNumber("99-999999"),
+		ingram.WithCountryCode("DE"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	products, err := client.PriceAndAvailability(ctx, &ingram.PriceAndAvailabilityRequest{
+		IncludeAvailability: true,
+		IncludePricing:      true,
+		IngramPartNumber:    "SKU1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 1 {
+		t.Fatalf("got %d products, want 1", len(products))
+	}
+	if got := products[0].Pricing.Customer().String(); got != "1049.90 EUR" {
+		t.Errorf("customer price = %s, want 1049.90 EUR", got)
+	}
+	if got := products[0].Availability.TotalAvailability; got != 12 {
+		t.Errorf("total availability = %d, want 12", got)
+	}
+
+	order, err := client.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: "40-12345"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := order.ServiceResponse.OrderDetailResponse.OrderStatus; got != "Shipped" {
+		t.Errorf("order status = %s, want Shipped", got)
+	}
+
+	_, err = client.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: "40-99999"})
+	if !errors.Is(err, cassette.ErrInteractionNotFound) {
+		t.Errorf("unknown order returned %v, want ErrInteractionNotFound", err)
+	}
+}
+
+func TestRecordRedacts(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddOrder(ingram.OrderDetailResponse{OrderNumber: "40-12345"})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = srv.Server.Client().Transport
+	client, err := srv.Client(
+		ingram.WithHTTPClient(rec.Client()),
+		ingram.WithCustomerNumber("20-222222"),
+		ingram.WithCountryCode("DE"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.OrderDetail(context.Background(), &ingram.OrderDetailRequest{OrderNumber: "40-12345"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rec.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{ingramtest.ClientID, ingramtest.ClientSecret, ingramtest.AccessToken, "20-222222"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains %s", secret)
+		}
+	}
+	if !strings.Contains(string(b), cassette.Redacted) {
+		t.Error("cassette contains no redacted values")
+	}
+}
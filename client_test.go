@@ -0,0 +1,215 @@
+package ingram_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+func newTestClient(t *testing.T, srv *ingramtest.Server, options ...ingram.OptionFunc) *ingram.Ingram {
+	t.Helper()
+
+	client, err := srv.Client(append([]ingram.OptionFunc{
+		ingram.WithCustomerNumber("20-222222"),
+		ingram.WithCountryCode("DE"),
+	}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestClient(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{
+		IngramPartNumber: "SKU1",
+		Pricing:          ingram.Pricing{CurrencyCode: "EUR", CustomerPrice: ingram.MustParseDecimal("19.99")},
+	})
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	token, err := client.GetOAuthToken(ctx, ingramtest.ClientID, ingramtest.ClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != ingramtest.AccessToken {
+		t.Errorf("access token = %s, want %s", token.AccessToken, ingramtest.AccessToken)
+	}
+
+	products, err := client.PriceAndAvailability(ctx, &ingram.PriceAndAvailabilityRequest{
+		IncludeAvailability: true,
+		IncludePricing:      true,
+		IngramPartNumber:    "SKU1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 1 || products[0].Pricing.Customer().String() != "19.99 EUR" {
+		t.Errorf("price and availability = %+v", products)
+	}
+
+	price := ingram.MustParseDecimal("19.99")
+	created, err := client.CreateOrderV5(ctx, &ingram.OrderCreateRequest{
+		OrderCreateDetails: ingram.OrderCreateDetails{
+			CustomerPurchaseOrderNumber: "PO-1",
+			Lines:                       []ingram.Line{{IngramPartNumber: "SKU1", Quantity: 2, UnitPrice: &price}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responses := created.ServiceResponse.OrderSummary.OrderCreateResponses
+	if len(responses) != 1 {
+		t.Fatalf("got %d order create responses, want 1", len(responses))
+	}
+	if got := responses[0].OrderAmount.String(); got != "39.98" {
+		t.Errorf("order amount = %s, want 39.98", got)
+	}
+	orders := srv.CreatedOrders()
+	if len(orders) != 1 || orders[0].RequestPreamble.CustomerNumber != "20-222222" {
+		t.Errorf("created orders = %+v", orders)
+	}
+
+	detail, err := client.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: responses[0].GlobalOrderID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := detail.ServiceResponse.OrderDetailResponse.CustomerOrderNumber; got != "PO-1" {
+		t.Errorf("customer order number = %s, want PO-1", got)
+	}
+
+	if got := srv.Requests(ingramtest.TokenEndpoint); got != 2 {
+		t.Errorf("token requested %d times, want 2 (explicitly and once cached)", got)
+	}
+}
+
+func TestClientRetriesRateLimitedRequests(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(ingramtest.PriceAndAvailabilityEndpoint, ingramtest.Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second})
+	// The backoff exceeds the test timeout, so the retry has to honor Retry-After.
+	client := newTestClient(t, srv, ingram.WithRetry(2, time.Hour))
+
+	start := time.Now()
+	_, err := client.PriceAndAvailability(context.Background(), &ingram.PriceAndAvailabilityRequest{
+		IncludeAvailability: true,
+		IncludePricing:      true,
+		IngramPartNumber:    "SKU1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Requests(ingramtest.PriceAndAvailabilityEndpoint); got != 2 {
+		t.Errorf("sent %d requests, want 2", got)
+	}
+	if d := time.Since(start); d < time.Second {
+		t.Errorf("retried after %s, want Retry-After of 1s", d)
+	}
+}
+
+func TestClientDoesNotRetryFailedOrders(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(ingramtest.OrderCreateEndpoint, ingramtest.Fault{StatusCode: http.StatusInternalServerError})
+	srv.InjectFault(ingramtest.OrderDetailEndpoint, ingramtest.Fault{StatusCode: http.StatusInternalServerError})
+	client := newTestClient(t, srv, ingram.WithRetry(3, time.Millisecond))
+	ctx := context.Background()
+
+	_, err := client.CreateOrderV5(ctx, &ingram.OrderCreateRequest{
+		OrderCreateDetails: ingram.OrderCreateDetails{CustomerPurchaseOrderNumber: "PO-1"},
+	})
+	var apiErr *ingram.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("order create returned %v, want a 500 APIError", err)
+	}
+	if got := srv.Requests(ingramtest.OrderCreateEndpoint); got != 1 {
+		t.Errorf("sent %d order create requests, want 1", got)
+	}
+
+	// GET requests are idempotent and retried.
+	_, err = client.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: "40-1"})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("order detail returned %v, want a 404 APIError after the retry", err)
+	}
+	if got := srv.Requests(ingramtest.OrderDetailEndpoint); got != 2 {
+		t.Errorf("sent %d order detail requests, want 2", got)
+	}
+}
+
+func TestClientMalformedJSON(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(ingramtest.PriceAndAvailabilityEndpoint, ingramtest.Fault{MalformedJSON: true})
+	client := newTestClient(t, srv, ingram.WithRetry(3, time.Millisecond))
+
+	_, err := client.PriceAndAvailability(context.Background(), &ingram.PriceAndAvailabilityRequest{
+		IncludeAvailability: true,
+		IncludePricing:      true,
+		IngramPartNumber:    "SKU1",
+	})
+	var reqErr *ingram.RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("malformed response returned %v, want *RequestError", err)
+	}
+	// The request succeeded, only its response couldn't be decoded, so it isn't retried.
+	if got := srv.Requests(ingramtest.PriceAndAvailabilityEndpoint); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
+
+func TestClientSandbox(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddOrder(ingram.OrderDetailResponse{OrderNumber: "40-1"})
+
+	var paths []string
+	client := newTestClient(t, srv,
+		ingram.EnableSandbox(),
+		ingram.WithMiddleware(ingram.BeforeRequest(func(_ context.Context, call *ingram.Call) error {
+			paths = append(paths, call.Request.URL.Path)
+			return nil
+		})),
+	)
+
+	_, err := client.OrderDetail(context.Background(), &ingram.OrderDetailRequest{OrderNumber: "40-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/oauth/oauth20/token", "/sandbox/resellers/v5/orders/40-1"}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("sent %v, want %v", paths, want)
+	}
+}
+
+func TestClientCorrelationID(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddOrder(ingram.OrderDetailResponse{OrderNumber: "40-1"})
+	client := newTestClient(t, srv)
+
+	var echoed string
+	ctx := ingram.ContextWithCorrelationID(context.Background(), "corr-42")
+	_, err := client.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: "40-1"}, ingram.CaptureCorrelationID(&echoed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if echoed != "corr-42" {
+		t.Errorf("echoed correlation id = %q, want corr-42", echoed)
+	}
+
+	_, err = client.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: "40-2"}, ingram.CorrelationID("corr-43"))
+	var apiErr *ingram.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("unknown order returned %v, want *APIError", err)
+	}
+	if apiErr.CorrelationID != "corr-43" || apiErr.ResponseCorrelationID != "corr-43" {
+		t.Errorf("correlation ids = %q, %q, want corr-43", apiErr.CorrelationID, apiErr.ResponseCorrelationID)
+	}
+}
@@ -0,0 +1,98 @@
+// Command ingram-webhooks generates, delivers and replays Ingram webhooks against a local consumer.
+//
+//	ingram-webhooks generate -type IM::order_shipped [-line-status IM::SHIPPED,IM::SALES_HOLD]
+//	ingram-webhooks send -url http://localhost:8080/webhook -secret s3cr3t -type IM::order_hold
+//	ingram-webhooks replay -url http://localhost:8080/webhook -secret s3cr3t -dir ./captured
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/enthus-golang/ingram"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "generate":
+		err = generate(os.Args[2:])
+	case "send":
+		err = send(os.Args[2:])
+	case "replay":
+		err = replay(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ingram-webhooks generate|send|replay [flags]")
+	os.Exit(2)
+}
+
+func simulate(fs *flag.FlagSet, args []string) (*ingram.Webhook, error) {
+	eventType := fs.String("type", string(ingram.OrderShipped), "webhook event type")
+	lineStatuses := fs.String("line-status", "", "comma separated line statuses of order events")
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []ingram.WebhookLineStatus
+	if *lineStatuses != "" {
+		for _, s := range strings.Split(*lineStatuses, ",") {
+			statuses = append(statuses, ingram.WebhookLineStatus(s))
+		}
+	}
+
+	return ingram.SimulateWebhook(ingram.WebhookEventType(*eventType), statuses...)
+}
+
+func generate(args []string) error {
+	webhook, err := simulate(flag.NewFlagSet("generate", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(webhook)
+}
+
+func send(args []string) error {
+	fs := flag.NewFlagSet("send", flag.ExitOnError)
+	url := fs.String("url", "http://localhost:8080/webhook", "consumer URL")
+	secret := fs.String("secret", "", "secret key to sign the webhook with")
+	webhook, err := simulate(fs, args)
+	if err != nil {
+		return err
+	}
+
+	return ingram.DeliverWebhook(context.Background(), *url, *secret, webhook)
+}
+
+func replay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	url := fs.String("url", "http://localhost:8080/webhook", "consumer URL")
+	secret := fs.String("secret", "", "secret key to sign the webhooks with")
+	dir := fs.String("dir", ".", "directory containing captured webhook payloads")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	return ingram.ReplayWebhooks(context.Background(), *dir, *url, *secret)
+}
@@ -0,0 +1,387 @@
+// Command ingram runs ad-hoc operations against the Ingram API.
+//
+//	ingram token
+//	ingram pa [-o table|json|csv] <sku...>
+//	ingram pa-csv -i parts.csv
+//	ingram order get [-o table|json|csv] <number>
+//	ingram order create -f order.json
+//	ingram order import -f orders.csv|orders.json [-dry-run]
+//
+// All commands write to stdout, or to the file given with -out. pa-csv and order import only
+// write CSV and reject other -o formats.
+//
+// Credentials and defaults are read from a JSON or YAML config file (-config, default
+// $HOME/.config/ingram/config.json) and the INGRAM_* environment variables of ingram.Config,
+// e.g. INGRAM_CLIENT_ID, INGRAM_CLIENT_SECRET, INGRAM_CUSTOMER_NUMBER and INGRAM_COUNTRY_CODE,
+// which take precedence over the file.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/enthus-golang/ingram"
+)
+
+type command struct {
+	flags      *flag.FlagSet
+	configPath string
+	sandbox    bool
+	baseURL    string
+	output     string
+	outPath    string
+	config     ingram.Config
+	client     *ingram.Ingram
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var err error
+	switch os.Args[1] {
+	case "token":
+		err = token(ctx, os.Args[2:])
+	case "pa":
+		err = priceAndAvailability(ctx, os.Args[2:])
+	case "pa-csv":
+		err = bulkPriceAndAvailability(ctx, os.Args[2:])
+	case "order":
+		if len(os.Args) < 3 {
+			usage()
+		}
+		switch os.Args[2] {
+		case "get":
+			err = orderDetail(ctx, os.Args[3:])
+		case "create":
+			err = createOrder(ctx, os.Args[3:])
+		case "import":
+			err = importOrders(ctx, os.Args[3:])
+		default:
+			usage()
+		}
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ingram token | pa <sku...> | pa-csv -i <file> | order get <number> | order create -f <file> | order import -f <file>")
+	os.Exit(2)
+}
+
+func newCommand(name string) *command {
+	c := &command{flags: flag.NewFlagSet(name, flag.ExitOnError)}
+	home, _ := os.UserHomeDir()
+	c.flags.StringVar(&c.configPath, "config", filepath.Join(home, ".config", "ingram", "config.json"), "config file")
+	c.flags.BoolVar(&c.sandbox, "sandbox", false, "use the Ingram sandbox")
+	c.flags.StringVar(&c.baseURL, "base-url", "", "override the Ingram API base URL")
+	c.flags.StringVar(&c.output, "o", "table", "output format: table, json or csv")
+	c.flags.StringVar(&c.outPath, "out", "", "file to write the output to, defaults to stdout")
+	return c
+}
+
+// csvOnly rejects -o formats other than csv for commands that only write CSV.
+func (c *command) csvOnly() error {
+	set := false
+	c.flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == "o"
+	})
+	if set && c.output != "csv" {
+		return fmt.Errorf("%s only writes csv, not %s", c.flags.Name(), c.output)
+	}
+	return nil
+}
+
+// writeOutput calls fn with the -out file, or stdout.
+func (c *command) writeOutput(fn func(w io.Writer) error) error {
+	if c.outPath == "" {
+		return fn(os.Stdout)
+	}
+
+	f, err := os.Create(c.outPath)
+	if err != nil {
+		return err
+	}
+	err = fn(f)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+// write prints t in the -o format, or raw as JSON.
+func (c *command) write(raw interface{}, t *table) error {
+	return c.writeOutput(func(w io.Writer) error {
+		return write(w, c.output, raw, t)
+	})
+}
+
+// parse parses args, loads the configuration and creates the client.
+func (c *command) parse(args []string) error {
+	err := c.parseConfig(args)
+	if err != nil {
+		return err
+	}
+
+	c.client, err = c.config.New()
+	return err
+}
+
+// parseConfig parses args and loads the configuration without creating a client.
+func (c *command) parseConfig(args []string) error {
+	err := c.flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	err = c.loadConfig(c.configPath)
+	if err != nil {
+		return err
+	}
+	if c.sandbox {
+		c.config.Sandbox = true
+	}
+	if c.baseURL != "" {
+		c.config.BaseURL = c.baseURL
+	}
+
+	return nil
+}
+
+func (c *command) loadConfig(path string) error {
+	config, err := ingram.LoadConfig(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		c.config = *config
+	}
+
+	return c.config.LoadEnv()
+}
+
+func token(ctx context.Context, args []string) error {
+	c := newCommand("token")
+	err := c.parse(args)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.client.GetOAuthToken(ctx, c.config.ClientID, c.config.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	out := &table{header: []string{"Access token", "Type", "Expires in"}}
+	out.add(t.AccessToken, t.TokenType, t.ExpiresIn)
+	return c.write(t, out)
+}
+
+func priceAndAvailability(ctx context.Context, args []string) error {
+	c := newCommand("pa")
+	err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	if c.flags.NArg() == 0 {
+		return errors.New("usage: ingram pa <sku...>")
+	}
+
+	var products []ingram.PriceAndAvailabilityResponse
+	skus := c.flags.Args()
+	for len(skus) > 0 {
+		n := len(skus)
+		if n > ingram.MaxPriceAndAvailabilityProducts {
+			n = ingram.MaxPriceAndAvailabilityProducts
+		}
+		batch := make([]ingram.Product, n)
+		for k, sku := range skus[:n] {
+			batch[k] = ingram.Product{IngramPartNumber: sku}
+		}
+		skus = skus[n:]
+
+		response, err := c.client.PriceAndAvailabilityBatch(ctx, &ingram.PriceAndAvailabilityBatchRequest{
+			IncludeAvailability: true,
+			IncludePricing:      true,
+			Products:            batch,
+		})
+		if err != nil {
+			return err
+		}
+		products = append(products, response...)
+	}
+
+	out := &table{header: []string{"SKU", "Vendor part number", "Description", "Status", "Customer price", "Retail price", "Currency", "Available"}}
+	for _, p := range products {
+		status := p.ProductStatusCode
+		if p.ProductStatusMessage != "" {
+			status += " " + p.ProductStatusMessage
+		}
+		out.add(p.IngramPartNumber, p.VendorPartNumber, p.Description, status, p.Pricing.CustomerPrice, p.Pricing.RetailPrice, p.Pricing.CurrencyCode, p.Availability.TotalAvailability)
+	}
+	return c.write(products, out)
+}
+
+func bulkPriceAndAvailability(ctx context.Context, args []string) error {
+	c := newCommand("pa-csv")
+	input := c.flags.String("i", "", "CSV file with an ingramPartNumber or vendorPartNumber column")
+	err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	if *input == "" {
+		return errors.New("usage: ingram pa-csv -i <file> [-out <file>]")
+	}
+	err = c.csvOnly()
+	if err != nil {
+		return err
+	}
+
+	r, err := os.Open(*input)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return c.writeOutput(func(w io.Writer) error {
+		return c.client.BulkPriceAndAvailability(ctx, r, w, ingram.BulkPriceAndAvailabilityOptions{})
+	})
+}
+
+func orderDetail(ctx context.Context, args []string) error {
+	c := newCommand("order get")
+	err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	if c.flags.NArg() != 1 {
+		return errors.New("usage: ingram order get <number>")
+	}
+
+	response, err := c.client.OrderDetail(ctx, &ingram.OrderDetailRequest{
+		OrderNumber: c.flags.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+	order := response.ServiceResponse.OrderDetailResponse
+
+	out := &table{header: []string{"Order", "Status", "Line", "Line status", "SKU", "Vendor part number", "Requested", "Confirmed", "Backorder", "Unit price", "Currency"}}
+	for _, l := range order.Lines {
+		out.add(order.OrderNumber, order.OrderStatus, l.LineNumber, l.LineStatus, l.PartNumber, l.ManufacturerPartNumber, l.RequestedQuantity, l.ConfirmedQuantity, l.BackorderQuantity, l.UnitPrice, order.CurrencyCode)
+	}
+	return c.write(response, out)
+}
+
+func createOrder(ctx context.Context, args []string) error {
+	c := newCommand("order create")
+	file := c.flags.String("f", "", "JSON file containing the order create request")
+	err := c.parse(args)
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("usage: ingram order create -f <file>")
+	}
+
+	b, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var order ingram.OrderCreateRequest
+	err = json.Unmarshal(b, &order)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+
+	response, err := c.client.CreateOrderV5(ctx, &order)
+	if err != nil {
+		return err
+	}
+
+	out := &table{header: []string{"Order", "Type", "Timestamp", "Lines ok", "Lines with error", "Lines with warning", "Amount"}}
+	for _, o := range response.ServiceResponse.OrderSummary.OrderCreateResponses {
+		out.add(o.GlobalOrderID, o.OrderType, o.OrderTimestamp, o.NumberOfLinesWithSuccess, o.NumberOfLinesWithError, o.NumberOfLinesWithWarning, o.OrderAmount)
+	}
+	return c.write(response, out)
+}
+
+func importOrders(ctx context.Context, args []string) error {
+	c := newCommand("order import")
+	file := c.flags.String("f", "", "CSV or JSON file containing the orders")
+	dryRun := c.flags.Bool("dry-run", false, "validate the orders without placing them")
+	err := c.parseConfig(args)
+	if err != nil {
+		return err
+	}
+	err = c.csvOnly()
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		// Validating orders doesn't call the API, so no credentials are needed.
+		c.client, err = ingram.New(ingram.WithCustomerNumber(c.config.CustomerNumber), ingram.WithCountryCode(c.config.CountryCode))
+	} else {
+		c.client, err = c.config.New()
+	}
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("usage: ingram order import -f <file> [-dry-run] [-out <file>]")
+	}
+
+	r, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	preamble := ingram.RequestPreamble{
+		CustomerNumber: c.config.CustomerNumber,
+		ISOCountryCode: c.config.CountryCode,
+	}
+	var orders []ingram.OrderCreateRequest
+	var failed []ingram.OrderImportResult
+	if strings.EqualFold(filepath.Ext(*file), ".json") {
+		orders, err = ingram.ReadOrdersJSON(r, preamble)
+	} else {
+		orders, failed, err = ingram.ReadOrdersCSV(r, preamble)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+
+	results := append(failed, c.client.ImportOrders(ctx, orders, *dryRun)...)
+
+	err = c.writeOutput(func(w io.Writer) error {
+		return ingram.WriteOrderImportReport(w, results)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			return errors.New("some orders failed")
+		}
+	}
+	return nil
+}
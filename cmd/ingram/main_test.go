@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+// testArgs points the command at srv and returns the common arguments and the directory for test files.
+func testArgs(t *testing.T, srv *ingramtest.Server) ([]string, string) {
+	t.Helper()
+
+	t.Setenv("INGRAM_CLIENT_ID", ingramtest.ClientID)
+	t.Setenv("INGRAM_CLIENT_SECRET", ingramtest.ClientSecret)
+	t.Setenv("INGRAM_CUSTOMER_NUMBER", "20-222222")
+	t.Setenv("INGRAM_COUNTRY_CODE", "DE")
+	dir := t.TempDir()
+	args := []string{"-config", filepath.Join(dir, "missing.json")}
+	if srv != nil {
+		args = append(args, "-base-url", srv.URL)
+	}
+	return args, dir
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestPriceAndAvailabilityOutput(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1", Description: "Laptop"})
+	args, dir := testArgs(t, srv)
+	out := filepath.Join(dir, "out.json")
+
+	err := priceAndAvailability(context.Background(), append(args, "-o", "json", "-out", out, "SKU1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var products []ingram.PriceAndAvailabilityResponse
+	err = json.Unmarshal([]byte(readTestFile(t, out)), &products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 1 || products[0].Description != "Laptop" {
+		t.Errorf("products = %+v", products)
+	}
+
+	out = filepath.Join(dir, "out.csv")
+	err = priceAndAvailability(context.Background(), append(args, "-o", "csv", "-out", out, "SKU1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, out); !strings.HasPrefix(got, "SKU,") || !strings.Contains(got, "SKU1,,Laptop") {
+		t.Errorf("csv output = %s", got)
+	}
+}
+
+func TestBulkPriceAndAvailabilityOutput(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1", Description: "Laptop"})
+	args, dir := testArgs(t, srv)
+	input := filepath.Join(dir, "parts.csv")
+	writeTestFile(t, input, "ingramPartNumber\nSKU1\n")
+	out := filepath.Join(dir, "prices.csv")
+
+	err := bulkPriceAndAvailability(context.Background(), append(args, "-i", input, "-o", "csv", "-out", out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, out); !strings.Contains(got, "SKU1,,Laptop") {
+		t.Errorf("output = %s", got)
+	}
+
+	err = bulkPriceAndAvailability(context.Background(), append(args, "-i", input, "-o", "json"))
+	if err == nil || !strings.Contains(err.Error(), "only writes csv") {
+		t.Errorf("-o json = %v, want it rejected", err)
+	}
+}
+
+func TestImportOrdersDryRunWithoutCredentials(t *testing.T) {
+	args, dir := testArgs(t, nil)
+	t.Setenv("INGRAM_CLIENT_ID", "")
+	t.Setenv("INGRAM_CLIENT_SECRET", "")
+	orders := filepath.Join(dir, "orders.csv")
+	writeTestFile(t, orders, "customerPoNumber,ingramPartNumber,quantity\nPO-1,SKU1,1\n")
+	report := filepath.Join(dir, "report.csv")
+
+	err := importOrders(context.Background(), append(args, "-f", orders, "-dry-run", "-out", report))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, report); !strings.Contains(got, "PO-1,1,valid") {
+		t.Errorf("report = %s", got)
+	}
+
+	err = importOrders(context.Background(), append(args, "-f", orders, "-dry-run", "-o", "table"))
+	if err == nil || !strings.Contains(err.Error(), "only writes csv") {
+		t.Errorf("-o table = %v, want it rejected", err)
+	}
+}
+
+func TestImportOrders(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	args, dir := testArgs(t, srv)
+	orders := filepath.Join(dir, "orders.csv")
+	writeTestFile(t, orders, "customerPoNumber,ingramPartNumber,quantity,unitPrice\nPO-1,SKU1,2,9.99\nPO-2,SKU2,x,\n")
+	report := filepath.Join(dir, "report.csv")
+
+	err := importOrders(context.Background(), append(args, "-f", orders, "-out", report))
+	if err == nil {
+		t.Error("import with an invalid row succeeded")
+	}
+	got := readTestFile(t, report)
+	if !strings.Contains(got, "PO-2,0,failed") || !strings.Contains(got, "PO-1,1,created,40-00001") {
+		t.Errorf("report = %s", got)
+	}
+	if created := srv.CreatedOrders(); len(created) != 1 {
+		t.Errorf("created %d orders, want 1", len(created))
+	}
+}
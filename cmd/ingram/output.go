@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(values ...interface{}) {
+	row := make([]string, len(values))
+	for n, v := range values {
+		row[n] = fmt.Sprint(v)
+	}
+	t.rows = append(t.rows, row)
+}
+
+// write prints t in format, or raw as JSON if format is "json".
+func write(w io.Writer, format string, raw interface{}, t *table) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(raw)
+	case "csv":
+		cw := csv.NewWriter(w)
+		err := cw.Write(t.header)
+		if err != nil {
+			return err
+		}
+		err = cw.WriteAll(t.rows)
+		if err != nil {
+			return err
+		}
+		return cw.Error()
+	case "table", "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,236 @@
+package ingram
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the client settings read by NewFromEnv and NewFromConfig.
+type Config struct {
+	ClientID       string `json:"clientId" yaml:"clientId" env:"INGRAM_CLIENT_ID" validate:"required"`
+	ClientSecret   string `json:"clientSecret" yaml:"clientSecret" env:"INGRAM_CLIENT_SECRET" validate:"required"`
+	Sandbox        bool   `json:"sandbox" yaml:"sandbox" env:"INGRAM_SANDBOX"`
+	BaseURL        string `json:"baseUrl" yaml:"baseUrl" env:"INGRAM_BASE_URL" validate:"omitempty,url"`
+	TokenURL       string `json:"tokenUrl" yaml:"tokenUrl" env:"INGRAM_TOKEN_URL" validate:"omitempty,url"`
+	CustomerNumber string `json:"customerNumber" yaml:"customerNumber" env:"INGRAM_CUSTOMER_NUMBER"`
+	CountryCode    string `json:"countryCode" yaml:"countryCode" env:"INGRAM_COUNTRY_CODE" validate:"omitempty,len=2"`
+	SenderID       string `json:"senderId" yaml:"senderId" env:"INGRAM_SENDER_ID"`
+	// Timeout limits every request attempt, see WithTimeout.
+	Timeout Duration `json:"timeout" yaml:"timeout" env:"INGRAM_TIMEOUT" validate:"min=0"`
+	// RetryAttempts and RetryBackoff configure WithRetry.
+	RetryAttempts int      `json:"retryAttempts" yaml:"retryAttempts" env:"INGRAM_RETRY_ATTEMPTS" validate:"min=0"`
+	RetryBackoff  Duration `json:"retryBackoff" yaml:"retryBackoff" env:"INGRAM_RETRY_BACKOFF" validate:"min=0"`
+	// RateLimit is the number of requests per second, RateLimitBurst the burst size, see NewRateLimiter.
+	RateLimit      float64 `json:"rateLimit" yaml:"rateLimit" env:"INGRAM_RATE_LIMIT" validate:"min=0"`
+	RateLimitBurst int     `json:"rateLimitBurst" yaml:"rateLimitBurst" env:"INGRAM_RATE_LIMIT_BURST" validate:"min=0"`
+}
+
+// Duration is a time.Duration written as string like "30s" in config files and environment variables.
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// ConfigError lists all missing or invalid config fields.
+type ConfigError struct {
+	Fields []string
+}
+
+func (e *ConfigError) Error() string {
+	return "invalid config: " + strings.Join(e.Fields, ", ")
+}
+
+// NewFromEnv creates a client configured by the INGRAM_* environment variables of Config.
+// options are applied last.
+func NewFromEnv(options ...OptionFunc) (*Ingram, error) {
+	var c Config
+	err := c.LoadEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.New(options...)
+}
+
+// NewFromConfig creates a client configured by the YAML or JSON file at path. Set environment
+// variables take precedence over the file. options are applied last.
+func NewFromConfig(path string, options ...OptionFunc) (*Ingram, error) {
+	c, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	err = c.LoadEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.New(options...)
+}
+
+// LoadConfig reads a config file, as JSON if its extension is .json and as YAML otherwise.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(b, &c)
+	} else {
+		err = yaml.Unmarshal(b, &c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// LoadEnv overwrites the fields of c with the environment variables that are set.
+func (c *Config) LoadEnv() error {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	var errs []error
+	for n := 0; n < t.NumField(); n++ {
+		key := t.Field(n).Tag.Get("env")
+		value, ok := os.LookupEnv(key)
+		if key == "" || !ok || value == "" {
+			continue
+		}
+
+		err := setConfigField(v.Field(n), value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setConfigField(field reflect.Value, value string) error {
+	if u, ok := field.Addr().Interface().(interface{ UnmarshalText([]byte) error }); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(i))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	}
+
+	return nil
+}
+
+// Validate returns a ConfigError listing every missing or invalid field.
+func (c *Config) Validate() error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return fmt.Sprintf("%s (%s)", field.Tag.Get("json"), field.Tag.Get("env"))
+	})
+
+	err := validate.Struct(c)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	configErr := &ConfigError{}
+	for _, fieldErr := range validationErrors {
+		switch fieldErr.Tag() {
+		case "required":
+			configErr.Fields = append(configErr.Fields, fieldErr.Field()+" is missing")
+		default:
+			configErr.Fields = append(configErr.Fields, fmt.Sprintf("%s is invalid (%s)", fieldErr.Field(), fieldErr.Tag()))
+		}
+	}
+
+	return configErr
+}
+
+// Options validates c and returns the options configuring a client accordingly.
+func (c *Config) Options() ([]OptionFunc, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []OptionFunc{WithOAuthCredentials(c.ClientID, c.ClientSecret)}
+	if c.Sandbox {
+		options = append(options, EnableSandbox())
+	}
+	if c.BaseURL != "" {
+		options = append(options, WithBaseURL(c.BaseURL))
+	}
+	if c.TokenURL != "" {
+		options = append(options, WithTokenURL(c.TokenURL))
+	}
+	if c.CustomerNumber != "" {
+		options = append(options, WithCustomerNumber(c.CustomerNumber))
+	}
+	if c.CountryCode != "" {
+		options = append(options, WithCountryCode(c.CountryCode))
+	}
+	if c.SenderID != "" {
+		options = append(options, WithSenderID(c.SenderID))
+	}
+	if c.Timeout > 0 {
+		options = append(options, WithTimeout(time.Duration(c.Timeout)))
+	}
+	if c.RetryAttempts > 0 {
+		options = append(options, WithRetry(c.RetryAttempts, time.Duration(c.RetryBackoff)))
+	}
+	if c.RateLimit > 0 {
+		options = append(options, WithRateLimiter(NewRateLimiter(c.RateLimit, c.RateLimitBurst)))
+	}
+
+	return options, nil
+}
+
+// New creates a client configured by c. options are applied last.
+func (c *Config) New(options ...OptionFunc) (*Ingram, error) {
+	configOptions, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(append(configOptions, options...)...)
+}
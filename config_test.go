@@ -0,0 +1,117 @@
+package ingram
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadEnv(t *testing.T) {
+	t.Setenv("INGRAM_CLIENT_ID", "id")
+	t.Setenv("INGRAM_SANDBOX", "true")
+	t.Setenv("INGRAM_TIMEOUT", "45s")
+	t.Setenv("INGRAM_RETRY_ATTEMPTS", "3")
+	t.Setenv("INGRAM_RATE_LIMIT", "2.5")
+	// Empty variables don't overwrite the field.
+	t.Setenv("INGRAM_CUSTOMER_NUMBER", "")
+
+	c := Config{ClientSecret: "file secret", CustomerNumber: "20-222222"}
+	err := c.LoadEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Config{
+		ClientID:       "id",
+		ClientSecret:   "file secret",
+		Sandbox:        true,
+		CustomerNumber: "20-222222",
+		Timeout:        Duration(45 * time.Second),
+		RetryAttempts:  3,
+		RateLimit:      2.5,
+	}
+	if c != want {
+		t.Errorf("config = %+v, want %+v", c, want)
+	}
+}
+
+func TestLoadEnvInvalid(t *testing.T) {
+	t.Setenv("INGRAM_SANDBOX", "maybe")
+	t.Setenv("INGRAM_TIMEOUT", "soon")
+	t.Setenv("INGRAM_RETRY_ATTEMPTS", "three")
+
+	var c Config
+	err := c.LoadEnv()
+	if err == nil {
+		t.Fatal("LoadEnv succeeded with invalid values")
+	}
+	for _, key := range []string{"INGRAM_SANDBOX", "INGRAM_TIMEOUT", "INGRAM_RETRY_ATTEMPTS"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q doesn't mention %s", err, key)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	c := Config{CountryCode: "DEU", BaseURL: "not a url", RetryAttempts: -1}
+	err := c.Validate()
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("error = %v, want *ConfigError", err)
+	}
+
+	want := []string{
+		"clientId (INGRAM_CLIENT_ID) is missing",
+		"clientSecret (INGRAM_CLIENT_SECRET) is missing",
+		"baseUrl (INGRAM_BASE_URL) is invalid (url)",
+		"countryCode (INGRAM_COUNTRY_CODE) is invalid (len)",
+		"retryAttempts (INGRAM_RETRY_ATTEMPTS) is invalid (min)",
+	}
+	if got := strings.Join(configErr.Fields, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("fields =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+
+	c = Config{ClientID: "id", ClientSecret: "secret"}
+	err = c.Validate()
+	if err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.json": `{"clientId": "id", "timeout": "1m30s", "retryBackoff": "250ms"}`,
+		"config.yaml": "clientId: id\ntimeout: 1m30s\nretryBackoff: 250ms\n",
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			err := os.WriteFile(path, []byte(content), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := LoadConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.ClientID != "id" || c.Timeout != Duration(90*time.Second) || c.RetryBackoff != Duration(250*time.Millisecond) {
+				t.Errorf("config = %+v", c)
+			}
+		})
+	}
+
+	path := filepath.Join(dir, "invalid.yaml")
+	err := os.WriteFile(path, []byte("timeout: soon\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadConfig(path)
+	if err == nil {
+		t.Error("LoadConfig succeeded with an invalid duration")
+	}
+}
@@ -0,0 +1,255 @@
+package ingram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number used for prices and amounts. The zero value is 0.
+//
+// Decimals are decoded losslessly from JSON numbers and strings, so 19.99 stays 19.99 instead of
+// becoming the nearest float64. Use Float64 and NewDecimalFromFloat to convert from and to float64.
+type Decimal struct {
+	// unscaled is the value multiplied by 10^scale, nil for 0.
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(1999, 2) is 19.99.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// NewDecimalFromFloat returns the shortest decimal representing f. NaN and infinities return 0.
+func NewDecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// maxDecimalScale bounds the number of decimal places and trailing zeros of parsed decimals, so an
+// input like "1e20000000" can't make ParseDecimal or later arithmetic allocate huge numbers.
+const maxDecimalScale = 1000
+
+// ParseDecimal parses a decimal number like "19.99", "-3" or "1.5e2". Values needing more than
+// 1000 decimal places or trailing zeros are rejected as out of range.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent := s, int64(0)
+	if n := strings.IndexAny(s, "eE"); n != -1 {
+		var err error
+		mantissa = s[:n]
+		exponent, err = strconv.ParseInt(s[n+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	digits := integer + fraction
+	if strings.TrimLeft(digits, "+-") == "" || strings.ContainsAny(fraction, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale := int64(len(fraction)) - exponent
+	if scale < -maxDecimalScale || scale > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q out of range", s)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return newDecimal(unscaled, int32(scale)), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input. It simplifies initializing constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func newDecimal(unscaled *big.Int, scale int32) Decimal {
+	if unscaled.Sign() == 0 {
+		return Decimal{scale: scale}
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns the unscaled value of d at scale, which must not be smaller than d.scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// align returns the unscaled values of d and other at their common scale.
+func (d Decimal) align(other Decimal) (*big.Int, *big.Int, int32) {
+	scale := d.scale
+	if other.scale > scale {
+		scale = other.scale
+	}
+	return d.rescale(scale), other.rescale(scale), scale
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := d.align(other)
+	return newDecimal(a.Add(a, b), scale)
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := d.align(other)
+	return newDecimal(a.Sub(a, b), scale)
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	return newDecimal(new(big.Int).Mul(d.int(), other.int()), d.scale+other.scale)
+}
+
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.int()), d.scale)
+}
+
+// Round rounds d half away from zero to places decimal places.
+func (d Decimal) Round(places int32) Decimal {
+	if d.scale <= places {
+		return newDecimal(d.rescale(places), places)
+	}
+
+	divisor := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.int(), divisor, new(big.Int))
+	if r.Abs(r).Mul(r, big.NewInt(2)).Cmp(divisor) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+	return newDecimal(q, places)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := d.align(other)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and other have the same value, regardless of their number of decimal places.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d with its decimal places, e.g. "19.90".
+func (d Decimal) String() string {
+	s := d.int().String()
+	if d.scale <= 0 {
+		if d.Sign() == 0 {
+			return "0"
+		}
+		return s + strings.Repeat("0", int(-d.scale))
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= int(d.scale) {
+		s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+	}
+	n := len(s) - int(d.scale)
+	return sign + s[:n] + "." + s[n:]
+}
+
+// MarshalJSON encodes d as JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string. null and empty strings decode as 0.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s, err := jsonScalar(b)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// ErrCurrencyMismatch is returned when adding or subtracting money in different currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an amount in a currency. Pricing, WebhookPriceResource and OrderDetailResponse return
+// their amounts as Money, other amounts can be converted with Decimal.In.
+type Money struct {
+	Amount   Decimal `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// In returns d as amount in currency.
+func (d Decimal) In(currency string) Money {
+	return Money{Amount: d, Currency: currency}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return m.Amount.Add(other.Amount).In(m.Currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return m.Amount.Sub(other.Amount).In(m.Currency), nil
+}
+
+// String formats m like "19.99 EUR".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+	return m.Amount.String() + " " + m.Currency
+}
@@ -0,0 +1,73 @@
+package ingram
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"19.99", "19.99"},
+		{"-3", "-3"},
+		{"1.5e2", "150"},
+		{"1.5E-2", "0.015"},
+		{"0.10", "0.10"},
+		{"1e1000", "1" + strings.Repeat("0", 1000)},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q): %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "-", "1.-2", "abc", "1e", "1e20000000", "1e-20000000", "1e1001", "1e99999999999"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var p Pricing
+	err := json.Unmarshal([]byte(`{"currencyCode":"EUR","retailPrice":19.99,"mapPrice":"","customerPrice":"12.50"}`), &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Retail().String(); got != "19.99 EUR" {
+		t.Errorf("Retail() = %s", got)
+	}
+	if !p.MAP().Amount.IsZero() {
+		t.Errorf("MAP() = %s, want 0", p.MAP())
+	}
+	if got := p.Customer().String(); got != "12.50 EUR" {
+		t.Errorf("Customer() = %s", got)
+	}
+
+	err = json.Unmarshal([]byte(`{"retailPrice":1e20000000}`), &p)
+	if err == nil {
+		t.Error("decoding an out of range price succeeded")
+	}
+}
+
+func TestMoney(t *testing.T) {
+	a := MustParseDecimal("1.10").In("EUR")
+	sum, err := a.Add(NewDecimal(5, 2).In("EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sum.String(); got != "1.15 EUR" {
+		t.Errorf("sum = %s", got)
+	}
+	if _, err = a.Sub(a.Amount.In("USD")); err == nil {
+		t.Error("subtracting USD from EUR succeeded")
+	}
+}
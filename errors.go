@@ -0,0 +1,56 @@
+package ingram
+
+import (
+	"errors"
+	"fmt"
+)
+
+// APIError is returned for requests Ingram answered with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+	// CorrelationID is the IM-CorrelationID sent with the request.
+	CorrelationID string
+	// ResponseCorrelationID is the IM-CorrelationID echoed by Ingram, if any.
+	ResponseCorrelationID string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s (correlation id %s)", e.Status, string(e.Body), e.CorrelationID)
+}
+
+// RequestError is returned for requests that failed without an answer of Ingram, e.g. on network
+// errors and timeouts, and for responses that couldn't be read or decoded. Err is the cause, so
+// errors.Is(err, context.DeadlineExceeded) keeps working.
+type RequestError struct {
+	Err error
+	// CorrelationID is the IM-CorrelationID sent with the request.
+	CorrelationID string
+	// ResponseCorrelationID is the IM-CorrelationID echoed by Ingram, if a response was received.
+	ResponseCorrelationID string
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (correlation id %s)", e.Err, e.CorrelationID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// requestError wraps err in a *RequestError carrying the correlation IDs of call. nil, *APIError and
+// *RequestError are returned unchanged.
+func requestError(call *Call, err error) error {
+	var apiErr *APIError
+	var reqErr *RequestError
+	if err == nil || errors.As(err, &apiErr) || errors.As(err, &reqErr) {
+		return err
+	}
+
+	e := &RequestError{Err: err, CorrelationID: call.Request.Header.Get("IM-CorrelationID")}
+	if call.Response != nil {
+		e.ResponseCorrelationID = call.Response.Header.Get("IM-CorrelationID")
+	}
+	return e
+}
@@ -0,0 +1,59 @@
+package ingram_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+func TestRequestError(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddOrder(ingram.OrderDetailResponse{OrderNumber: "40-1"})
+
+	client, err := srv.Client(
+		ingram.WithCustomerNumber("123"),
+		ingram.WithCountryCode("DE"),
+		ingram.WithTimeout(50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := ingram.ContextWithCorrelationID(context.Background(), "corr-1")
+	request := &ingram.OrderDetailRequest{OrderNumber: "40-1"}
+
+	srv.InjectFault(ingramtest.OrderDetailEndpoint, ingramtest.Fault{MalformedJSON: true})
+	_, err = client.OrderDetail(ctx, request)
+	var reqErr *ingram.RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("malformed JSON returned %T %v, want *RequestError", err, err)
+	}
+	if reqErr.CorrelationID != "corr-1" || reqErr.ResponseCorrelationID != "corr-1" {
+		t.Errorf("correlation ids = %q, %q", reqErr.CorrelationID, reqErr.ResponseCorrelationID)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("cause %v is not a JSON error", reqErr.Err)
+	}
+
+	srv.InjectFault(ingramtest.OrderDetailEndpoint, ingramtest.Fault{Latency: time.Second})
+	_, err = client.OrderDetail(ctx, request)
+	if !errors.As(err, &reqErr) || reqErr.CorrelationID != "corr-1" {
+		t.Fatalf("timeout returned %v, want *RequestError with correlation id", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeout error %v does not unwrap to context.DeadlineExceeded", err)
+	}
+
+	srv.InjectFault(ingramtest.OrderDetailEndpoint, ingramtest.Fault{StatusCode: 404})
+	_, err = client.OrderDetail(ctx, request)
+	if _, ok := err.(*ingram.APIError); !ok {
+		t.Errorf("404 returned %T %v, want *APIError", err, err)
+	}
+}
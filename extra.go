@@ -0,0 +1,109 @@
+package ingram
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// knownFields caches the lower-cased JSON keys of struct types, as encoding/json matches keys case-insensitively.
+var knownFields sync.Map
+
+// unmarshalExtra decodes data into v, a pointer to a struct without UnmarshalJSON method, and returns
+// the fields of data not mapped to v, or nil if there are none.
+func unmarshalExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return nil, nil
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	for key := range fields {
+		if known[strings.ToLower(key)] {
+			delete(fields, key)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return fields, nil
+}
+
+func jsonFields(t reflect.Type) map[string]bool {
+	if known, ok := knownFields.Load(t); ok {
+		return known.(map[string]bool)
+	}
+
+	known := make(map[string]bool)
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for key := range jsonFields(field.Type) {
+				known[key] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+
+	knownFields.Store(t, known)
+	return known
+}
+
+// marshalExtra encodes v, a pointer to a struct without MarshalJSON method, followed by the extra
+// fields not mapped to v, so decoded responses encode without losing fields.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		if !known[strings.ToLower(key)] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	for _, key := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		if len(extra[key]) == 0 {
+			buf.WriteString("null")
+		} else {
+			buf.Write(extra[key])
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
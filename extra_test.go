@@ -0,0 +1,221 @@
+package ingram
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const webhookWithExtra = `{"topic":"resellers/orders","event":"im::updated","eventId":"1","resource":{"eventType":"IM::order_shipped","new0":0,` +
+	`"lines":[{"lineNumber":"1","newLine":"l","shipmentDetails":{"carrierCode":"UPS","packageDetails":[{"cartonNumber":"1","newPackage":1}],"newShipment":2},` +
+	`"serialNumberDetails":[{"serialNumber":"S","newSerial":3}]}]},"signature":"abc"}`
+
+func TestExtraRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		in   string
+	}{
+		{"order detail", &OrderDetailResponseServiceResponse{}, `{"serviceresponse":{"responsepreamble":{"responsestatus":"SUCCESS","new1":1},` +
+			`"orderdetailresponse":{"ordernumber":"40-1","BillToAddress":{"name":"A","new2":"b"},"lines":[{"linenumber":"1","new3":true}],` +
+			`"miscfeeline":[{"description":"fee","new4":[1]}],"new5":{"a":1}},"new6":null},"new7":"x"}`},
+		{"order create", &OrderCreateResponseServiceResponse{}, `{"serviceresponse":{"ordersummary":{"ordercreateresponse":[{"globalorderid":"1","lines":[{"linenumber":"1","new1":1}]}],"new2":2}}}`},
+		{"price and availability", &PriceAndAvailabilityResponse{}, `{"ingramPartNumber":"1","availability":{"availabilityByWarehouse":[{"location":"DE","new1":1}]},"pricing":{"currencyCode":"EUR","new2":2}}`},
+		{"token", &Token{}, `{"access_token":"a","token_type":"Bearer","expires_in":"86399","scope":"read"}`},
+		{"webhook", &Webhook{}, webhookWithExtra},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tt.in), tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var in, out interface{}
+			if err = json.Unmarshal([]byte(tt.in), &in); err != nil {
+				t.Fatal(err)
+			}
+			if err = json.Unmarshal(b, &out); err != nil {
+				t.Fatal(err)
+			}
+			missing := missingKeys(in, out, "")
+			for _, key := range missing {
+				t.Errorf("%s is lost on marshal", key)
+			}
+		})
+	}
+}
+
+// missingKeys returns the paths of the object keys of in that are not in out.
+func missingKeys(in, out interface{}, path string) []string {
+	var missing []string
+	switch in := in.(type) {
+	case map[string]interface{}:
+		out, _ := out.(map[string]interface{})
+		for key, value := range in {
+			o, ok := out[key]
+			if !ok {
+				missing = append(missing, path+"."+key)
+				continue
+			}
+			missing = append(missing, missingKeys(value, o, path+"."+key)...)
+		}
+	case []interface{}:
+		out, _ := out.([]interface{})
+		for n, value := range in {
+			if n < len(out) {
+				missing = append(missing, missingKeys(value, out[n], path)...)
+			}
+		}
+	}
+	return missing
+}
+
+func TestMarshalExtraKnownKeys(t *testing.T) {
+	p := ResponsePreamble{ResponseStatus: "SUCCESS", Extra: map[string]json.RawMessage{"ResponseStatus": json.RawMessage(`"x"`), "new": nil}}
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"responsestatus":"SUCCESS","statuscode":"","responsemessage":"","new":null}`
+	if string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+}
+
+func TestWebhookExtra(t *testing.T) {
+	var webhook Webhook
+	err := json.Unmarshal([]byte(webhookWithExtra), &webhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := webhook.Extra["signature"]; !ok {
+		t.Error("envelope Extra lacks signature")
+	}
+	order, ok := webhook.OrderResource()
+	if !ok {
+		t.Fatalf("resource is %T, want *WebhookResource", webhook.Resource)
+	}
+	line := order.Lines[0]
+	for _, extra := range []struct {
+		name  string
+		extra map[string]json.RawMessage
+		key   string
+	}{
+		{"resource", order.Extra, "new0"},
+		{"line", line.Extra, "newLine"},
+		{"shipment", line.ShipmentDetails.Extra, "newShipment"},
+		{"package", line.ShipmentDetails.PackageDetails[0].Extra, "newPackage"},
+		{"serial", line.SerialNumberDetails[0].Extra, "newSerial"},
+	} {
+		if _, ok := extra.extra[extra.key]; !ok {
+			t.Errorf("%s Extra = %v, want %s", extra.name, extra.extra, extra.key)
+		}
+	}
+}
+
+// extraTypes lists every type with an Extra field. TestExtraTypes keeps it complete.
+var extraTypes = []interface{}{
+	Availability{}, AvailabilityByWarehouse{}, ExtendedSpec{}, OrderCreateResponse{}, OrderCreateResponseLine{},
+	OrderCreateResponseServiceResponse{}, OrderDetailAddress{}, OrderDetailCommentLine{}, OrderDetailLine{},
+	OrderDetailMiscFeeLine{}, OrderDetailResponse{}, OrderDetailResponseServiceResponse{}, OrderDetailServiceResponse{},
+	OrderServiceResponse{}, OrderSummary{}, PriceAndAvailabilityResponse{}, Pricing{}, ResponsePreamble{}, Token{},
+	Webhook{}, WebhookPriceResource{}, WebhookResource{}, WebhookResourceLine{}, WebhookSerialNumberDetail{},
+	WebhookShipmentDetail{}, WebhookShipmentPackageDetail{}, WebhookStockResource{}, WebhookSubscription{},
+}
+
+// TestExtraTypes makes sure every struct with an Extra field has the UnmarshalJSON and MarshalJSON
+// methods keeping unknown fields.
+func TestExtraTypes(t *testing.T) {
+	fset := gotoken.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withExtra := make(map[string]bool)
+	methods := make(map[string]bool)
+	for _, file := range pkgs["ingram"].Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				s, ok := n.Type.(*ast.StructType)
+				if !ok {
+					return false
+				}
+				for _, field := range s.Fields.List {
+					for _, name := range field.Names {
+						if name.Name == "Extra" {
+							withExtra[n.Name.Name] = true
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if n.Recv == nil {
+					return false
+				}
+				recv := n.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					methods[ident.Name+"."+n.Name.Name] = true
+				}
+				return false
+			}
+			return true
+		})
+	}
+
+	listed := make(map[string]bool)
+	for _, v := range extraTypes {
+		listed[reflect.TypeOf(v).Name()] = true
+	}
+	for name := range withExtra {
+		if !listed[name] {
+			t.Errorf("%s has an Extra field but is missing in extraTypes", name)
+		}
+		for _, method := range []string{"UnmarshalJSON", "MarshalJSON"} {
+			if !methods[name+"."+method] {
+				t.Errorf("%s has an Extra field but no %s method", name, method)
+			}
+		}
+	}
+	for name := range listed {
+		if !withExtra[name] {
+			t.Errorf("%s is listed in extraTypes without an Extra field", name)
+		}
+	}
+
+	for _, v := range extraTypes {
+		p := reflect.New(reflect.TypeOf(v)).Interface()
+		err := json.Unmarshal([]byte(`{"unknownField":{"a":1}}`), p)
+		if err != nil {
+			t.Errorf("%T: %v", v, err)
+			continue
+		}
+		if extra := reflect.ValueOf(p).Elem().FieldByName("Extra"); !extra.IsValid() || extra.Len() != 1 {
+			t.Errorf("%T keeps %v as extra fields, want the unknown field", v, extra)
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Errorf("%T: %v", v, err)
+			continue
+		}
+		if !strings.Contains(string(b), `"unknownField":{"a":1}`) {
+			t.Errorf("%T marshals to %s, lacking the unknown field", v, b)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package ingram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlexInt is an integer Ingram sends either as JSON number or as string, e.g. quantities and line counts.
+// Empty strings and null decode as 0. Decimal is the counterpart for non-integer values.
+type FlexInt int64
+
+func (i FlexInt) Int() int {
+	return int(i)
+}
+
+func (i FlexInt) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// UnmarshalJSON decodes a JSON number or string. Numbers with a zero fraction like "2.0" are accepted.
+func (i *FlexInt) UnmarshalJSON(b []byte) error {
+	s, err := jsonScalar(b)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*i = 0
+		return nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		*i = FlexInt(v)
+		return nil
+	}
+
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	integer := d.Round(0)
+	if !integer.Equal(d) || !integer.int().IsInt64() {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	*i = FlexInt(integer.int().Int64())
+	return nil
+}
+
+// jsonScalar returns the JSON number or the content of the JSON string b, and "" for null and empty strings.
+func jsonScalar(b []byte) (string, error) {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return "", nil
+	}
+	if !bytes.HasPrefix(b, []byte(`"`)) {
+		return string(b), nil
+	}
+
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(s), nil
+}
@@ -0,0 +1,53 @@
+package ingram
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexIntJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FlexInt
+		wantErr bool
+	}{
+		{in: `3`, want: 3},
+		{in: `-12`, want: -12},
+		{in: `2.0`, want: 2},
+		{in: `"7"`, want: 7},
+		{in: `" 7 "`, want: 7},
+		{in: `"2.00"`, want: 2},
+		{in: `""`, want: 0},
+		{in: `null`, want: 0},
+		{in: `2.5`, wantErr: true},
+		{in: `"2.5"`, wantErr: true},
+		{in: `"abc"`, wantErr: true},
+		{in: `"1e30"`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		i := FlexInt(42)
+		err := json.Unmarshal([]byte(tt.in), &i)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %d, want error", tt.in, i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if i != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, i, tt.want)
+		}
+	}
+
+	b, err := json.Marshal(struct{ Quantity FlexInt }{Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"Quantity":5}` {
+		t.Errorf("Marshal = %s, want a number", b)
+	}
+}
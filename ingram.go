@@ -1,7 +1,17 @@
 package ingram
 
 import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const apiEndpoint = "https://api.ingrammicro.com"
@@ -10,10 +20,35 @@ type Ingram struct {
 	clientID     string
 	clientSecret string
 	isSandbox    bool
+	baseURL      string
 	endpoint     string
+	tokenURL     string
 	token        *Token
-	validate     *validator.Validate
-	logger       Logger
+	tokenMu      sync.Mutex
+	httpClient   *http.Client
+	rateLimiter  RateLimiter
+	timeout      time.Duration
+
+	retryAttempts int
+	retryBackoff  time.Duration
+	validate      *validator.Validate
+	logger        Logger
+	slogger       *slog.Logger
+	logBodies     bool
+	redactor      *redactor
+
+	customerNumber string
+	countryCode    string
+	senderID       string
+
+	timestampLocation *time.Location
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	telemetry      *telemetry
+
+	middlewares []Middleware
+	handler     Handler
 }
 
 type OptionFunc func(i *Ingram) error
@@ -33,6 +68,42 @@ func EnableSandbox() OptionFunc {
 	}
 }
 
+// WithBaseURL replaces the Ingram API host, e.g. with a regional gateway, a reverse proxy or a local
+// fake like ingramtest. The sandbox path and the OAuth token URL are derived from it unless
+// WithTokenURL is given.
+func WithBaseURL(baseURL string) OptionFunc {
+	return func(i *Ingram) error {
+		err := validateURL(baseURL)
+		if err != nil {
+			return err
+		}
+
+		i.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// WithTokenURL sets the full URL OAuth tokens are requested from. It is used as is, also in sandbox mode.
+func WithTokenURL(tokenURL string) OptionFunc {
+	return func(i *Ingram) error {
+		err := validateURL(tokenURL)
+		if err != nil {
+			return err
+		}
+
+		i.tokenURL = tokenURL
+		return nil
+	}
+}
+
+// WithHTTPClient sets the client used for all requests, e.g. to add timeouts or a custom transport.
+func WithHTTPClient(client *http.Client) OptionFunc {
+	return func(i *Ingram) error {
+		i.httpClient = client
+		return nil
+	}
+}
+
 func WithLogger(logger Logger) OptionFunc {
 	return func(i *Ingram) error {
 		i.logger = logger
@@ -40,9 +111,70 @@ func WithLogger(logger Logger) OptionFunc {
 	}
 }
 
+// WithSlog logs every request with its method, path, status, duration and correlation ID.
+// Successful requests are logged at debug level, client errors at warn and server errors at error level.
+func WithSlog(logger *slog.Logger) OptionFunc {
+	return func(i *Ingram) error {
+		i.slogger = logger
+		return nil
+	}
+}
+
+// WithBodyLogging adds request and response bodies to the WithSlog output. Credentials, tokens and webhook secret keys are redacted.
+func WithBodyLogging() OptionFunc {
+	return func(i *Ingram) error {
+		i.logBodies = true
+		return nil
+	}
+}
+
+// WithRedactedFields masks further JSON keys and form fields, e.g. "email" or "phonenumber", in all
+// logged requests and responses. Credentials, tokens and webhook secret keys are always masked.
+func WithRedactedFields(fields ...string) OptionFunc {
+	return func(i *Ingram) error {
+		i.redactor.add(fields...)
+		return nil
+	}
+}
+
+// WithTimestampLocation sets the location of timestamps this client receives without zone, overriding
+// SetTimestampLocation, e.g. for a tenant in another time zone.
+func WithTimestampLocation(loc *time.Location) OptionFunc {
+	return func(i *Ingram) error {
+		i.timestampLocation = loc
+		return nil
+	}
+}
+
+// WithCustomerNumber sets the customer number used for requests leaving it empty.
+func WithCustomerNumber(customerNumber string) OptionFunc {
+	return func(i *Ingram) error {
+		i.customerNumber = customerNumber
+		return nil
+	}
+}
+
+// WithCountryCode sets the ISO country code used for requests leaving it empty.
+func WithCountryCode(countryCode string) OptionFunc {
+	return func(i *Ingram) error {
+		i.countryCode = countryCode
+		return nil
+	}
+}
+
+// WithSenderID sends id as IM-SenderID with every request.
+func WithSenderID(id string) OptionFunc {
+	return func(i *Ingram) error {
+		i.senderID = id
+		return nil
+	}
+}
+
 func New(options ...OptionFunc) (*Ingram, error) {
 	i := &Ingram{
-		validate: validator.New(),
+		httpClient: http.DefaultClient,
+		validate:   validator.New(),
+		redactor:   newRedactor(),
 	}
 
 	for _, v := range options {
@@ -52,14 +184,49 @@ func New(options ...OptionFunc) (*Ingram, error) {
 		}
 	}
 
-	i.endpoint = apiEndpoint
+	var err error
+	i.telemetry, err = newTelemetry(i.tracerProvider, i.meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	i.handler = i.sendTraced
+	for n := len(i.middlewares) - 1; n >= 0; n-- {
+		i.handler = i.middlewares[n](i.handler)
+	}
+
+	if i.baseURL == "" {
+		i.baseURL = apiEndpoint
+	}
+
+	i.endpoint = i.baseURL
 	if i.isSandbox {
 		i.endpoint += "/sandbox"
 	}
 
+	if i.tokenURL == "" {
+		version := "30"
+		if i.isSandbox {
+			version = "20"
+		}
+		i.tokenURL = fmt.Sprintf("%s/oauth/oauth%s/token", i.baseURL, version)
+	}
+
 	return i, nil
 }
 
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid url %q", rawURL)
+	}
+
+	return nil
+}
+
 // Logger specifies the interface for all log operations.
 type Logger interface {
 	Printf(format string, v ...interface{})
@@ -0,0 +1,209 @@
+// Package ingrammock provides fakes of the ingram service interfaces.
+//
+// Every fake calls the function field of the same name and records the call. Unset functions
+// return ErrNotImplemented.
+//
+//	catalog := &ingrammock.CatalogService{
+//		PriceAndAvailabilityFunc: func(ctx context.Context, r *ingram.PriceAndAvailabilityRequest, opts ...ingram.CallOption) ([]ingram.PriceAndAvailabilityResponse, error) {
+//			return []ingram.PriceAndAvailabilityResponse{{IngramPartNumber: r.IngramPartNumber}}, nil
+//		},
+//	}
+package ingrammock
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/enthus-golang/ingram"
+)
+
+// ErrNotImplemented is returned by fake methods without a function set.
+var ErrNotImplemented = errors.New("ingrammock: method not implemented")
+
+var (
+	_ ingram.TokenService               = (*TokenService)(nil)
+	_ ingram.CatalogService             = (*CatalogService)(nil)
+	_ ingram.OrderService               = (*OrderService)(nil)
+	_ ingram.WebhookSubscriptionService = (*WebhookSubscriptionService)(nil)
+	_ ingram.Client                     = (*Client)(nil)
+)
+
+// Call is a recorded method call.
+type Call struct {
+	Method  string
+	Request interface{}
+
+	// seq orders the calls of different services.
+	seq uint64
+}
+
+// seq numbers all recorded calls.
+var seq atomic.Uint64
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, request interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Request: request, seq: seq.Add(1)})
+}
+
+// Calls returns all recorded calls in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+type TokenService struct {
+	recorder
+
+	GetOAuthTokenFunc func(ctx context.Context, clientID, clientSecret string) (*ingram.Token, error)
+}
+
+func (m *TokenService) GetOAuthToken(ctx context.Context, clientID, clientSecret string) (*ingram.Token, error) {
+	m.record("GetOAuthToken", clientID)
+	if m.GetOAuthTokenFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.GetOAuthTokenFunc(ctx, clientID, clientSecret)
+}
+
+type CatalogService struct {
+	recorder
+
+	PriceAndAvailabilityFunc      func(ctx context.Context, priceAndAvailabilityRequest *ingram.PriceAndAvailabilityRequest, opts ...ingram.CallOption) ([]ingram.PriceAndAvailabilityResponse, error)
+	PriceAndAvailabilityBatchFunc func(ctx context.Context, priceAndAvailabilityRequest *ingram.PriceAndAvailabilityBatchRequest, opts ...ingram.CallOption) ([]ingram.PriceAndAvailabilityResponse, error)
+}
+
+func (m *CatalogService) PriceAndAvailability(ctx context.Context, priceAndAvailabilityRequest *ingram.PriceAndAvailabilityRequest, opts ...ingram.CallOption) ([]ingram.PriceAndAvailabilityResponse, error) {
+	m.record("PriceAndAvailability", priceAndAvailabilityRequest)
+	if m.PriceAndAvailabilityFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.PriceAndAvailabilityFunc(ctx, priceAndAvailabilityRequest, opts...)
+}
+
+func (m *CatalogService) PriceAndAvailabilityBatch(ctx context.Context, priceAndAvailabilityRequest *ingram.PriceAndAvailabilityBatchRequest, opts ...ingram.CallOption) ([]ingram.PriceAndAvailabilityResponse, error) {
+	m.record("PriceAndAvailabilityBatch", priceAndAvailabilityRequest)
+	if m.PriceAndAvailabilityBatchFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.PriceAndAvailabilityBatchFunc(ctx, priceAndAvailabilityRequest, opts...)
+}
+
+type OrderService struct {
+	recorder
+
+	OrderDetailFunc   func(ctx context.Context, orderDetail *ingram.OrderDetailRequest, opts ...ingram.CallOption) (*ingram.OrderDetailResponseServiceResponse, error)
+	CreateOrderV5Func func(ctx context.Context, order *ingram.OrderCreateRequest, opts ...ingram.CallOption) (*ingram.OrderCreateResponseServiceResponse, error)
+}
+
+func (m *OrderService) OrderDetail(ctx context.Context, orderDetail *ingram.OrderDetailRequest, opts ...ingram.CallOption) (*ingram.OrderDetailResponseServiceResponse, error) {
+	m.record("OrderDetail", orderDetail)
+	if m.OrderDetailFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.OrderDetailFunc(ctx, orderDetail, opts...)
+}
+
+func (m *OrderService) CreateOrderV5(ctx context.Context, order *ingram.OrderCreateRequest, opts ...ingram.CallOption) (*ingram.OrderCreateResponseServiceResponse, error) {
+	m.record("CreateOrderV5", order)
+	if m.CreateOrderV5Func == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.CreateOrderV5Func(ctx, order, opts...)
+}
+
+type WebhookSubscriptionService struct {
+	recorder
+
+	CreateWebhookSubscriptionFunc func(ctx context.Context, subscription *ingram.WebhookSubscriptionRequest, opts ...ingram.CallOption) (*ingram.WebhookSubscription, error)
+	ListWebhookSubscriptionsFunc  func(ctx context.Context, subscriptions *ingram.WebhookSubscriptionsRequest, opts ...ingram.CallOption) ([]ingram.WebhookSubscription, error)
+	UpdateWebhookSubscriptionFunc func(ctx context.Context, subscription *ingram.WebhookSubscriptionRequest, opts ...ingram.CallOption) (*ingram.WebhookSubscription, error)
+	DeleteWebhookSubscriptionFunc func(ctx context.Context, subscription *ingram.DeleteWebhookSubscriptionRequest, opts ...ingram.CallOption) error
+}
+
+func (m *WebhookSubscriptionService) CreateWebhookSubscription(ctx context.Context, subscription *ingram.WebhookSubscriptionRequest, opts ...ingram.CallOption) (*ingram.WebhookSubscription, error) {
+	m.record("CreateWebhookSubscription", subscription)
+	if m.CreateWebhookSubscriptionFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.CreateWebhookSubscriptionFunc(ctx, subscription, opts...)
+}
+
+func (m *WebhookSubscriptionService) ListWebhookSubscriptions(ctx context.Context, subscriptions *ingram.WebhookSubscriptionsRequest, opts ...ingram.CallOption) ([]ingram.WebhookSubscription, error) {
+	m.record("ListWebhookSubscriptions", subscriptions)
+	if m.ListWebhookSubscriptionsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.ListWebhookSubscriptionsFunc(ctx, subscriptions, opts...)
+}
+
+func (m *WebhookSubscriptionService) UpdateWebhookSubscription(ctx context.Context, subscription *ingram.WebhookSubscriptionRequest, opts ...ingram.CallOption) (*ingram.WebhookSubscription, error) {
+	m.record("UpdateWebhookSubscription", subscription)
+	if m.UpdateWebhookSubscriptionFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return m.UpdateWebhookSubscriptionFunc(ctx, subscription, opts...)
+}
+
+func (m *WebhookSubscriptionService) DeleteWebhookSubscription(ctx context.Context, subscription *ingram.DeleteWebhookSubscriptionRequest, opts ...ingram.CallOption) error {
+	m.record("DeleteWebhookSubscription", subscription)
+	if m.DeleteWebhookSubscriptionFunc == nil {
+		return ErrNotImplemented
+	}
+	return m.DeleteWebhookSubscriptionFunc(ctx, subscription, opts...)
+}
+
+// Client fakes ingram.Client. Calls are recorded per embedded service, Calls returns them all.
+type Client struct {
+	TokenService
+	CatalogService
+	OrderService
+	WebhookSubscriptionService
+
+	DoFunc func(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...ingram.CallOption) error
+
+	doCalls recorder
+}
+
+// DoRequest is the request recorded for Do.
+type DoRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   interface{}
+}
+
+func (m *Client) Do(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...ingram.CallOption) error {
+	m.doCalls.record("Do", DoRequest{Method: method, Path: path, Query: query, Body: body})
+	if m.DoFunc == nil {
+		return ErrNotImplemented
+	}
+	return m.DoFunc(ctx, method, path, query, body, out, opts...)
+}
+
+// Calls returns the recorded calls of all services in order.
+func (c *Client) Calls() []Call {
+	var calls []Call
+	calls = append(calls, c.TokenService.Calls()...)
+	calls = append(calls, c.CatalogService.Calls()...)
+	calls = append(calls, c.OrderService.Calls()...)
+	calls = append(calls, c.WebhookSubscriptionService.Calls()...)
+	calls = append(calls, c.doCalls.Calls()...)
+	sort.Slice(calls, func(a, b int) bool {
+		return calls[a].seq < calls[b].seq
+	})
+
+	return calls
+}
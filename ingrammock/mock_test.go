@@ -0,0 +1,64 @@
+package ingrammock
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+)
+
+func TestClientCalls(t *testing.T) {
+	c := &Client{}
+	ctx := context.Background()
+
+	_, _ = c.OrderDetail(ctx, &ingram.OrderDetailRequest{OrderNumber: "1"})
+	_, _ = c.GetOAuthToken(ctx, "id", "secret")
+	_, _ = c.PriceAndAvailability(ctx, &ingram.PriceAndAvailabilityRequest{})
+
+	calls := c.Calls()
+	want := []string{"OrderDetail", "GetOAuthToken", "PriceAndAvailability"}
+	if len(calls) != len(want) {
+		t.Fatalf("got %d calls, want %d", len(calls), len(want))
+	}
+	for n, call := range calls {
+		if call.Method != want[n] {
+			t.Errorf("call %d is %s, want %s", n, call.Method, want[n])
+		}
+	}
+	if got := len(c.OrderService.Calls()); got != 1 {
+		t.Errorf("order service recorded %d calls, want 1", got)
+	}
+}
+
+func TestClientDo(t *testing.T) {
+	c := &Client{}
+	ctx := context.Background()
+
+	err := c.Do(ctx, "GET", "/resellers/v6/quotes/search", nil, nil, nil)
+	if err != ErrNotImplemented {
+		t.Errorf("error = %v, want ErrNotImplemented", err)
+	}
+
+	c.DoFunc = func(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...ingram.CallOption) error {
+		*out.(*string) = "Q1"
+		return nil
+	}
+	var out string
+	err = c.Do(ctx, "GET", "/resellers/v6/quotes/search", url.Values{"pageSize": {"10"}}, nil, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Q1" {
+		t.Errorf("output = %q, want Q1", out)
+	}
+
+	calls := c.Calls()
+	if len(calls) != 2 || calls[1].Method != "Do" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	request := calls[1].Request.(DoRequest)
+	if request.Method != "GET" || request.Path != "/resellers/v6/quotes/search" || request.Query.Get("pageSize") != "10" {
+		t.Errorf("recorded request = %+v", request)
+	}
+}
@@ -0,0 +1,474 @@
+// Package ingramtest provides an in-process fake of the Ingram API for integration tests.
+package ingramtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/enthus-golang/ingram"
+)
+
+const (
+	ClientID     = "ingramtest-client"
+	ClientSecret = "ingramtest-secret"
+	AccessToken  = "ingramtest-token"
+)
+
+// Endpoint identifies a faked API endpoint for fault injection.
+type Endpoint string
+
+const (
+	TokenEndpoint                Endpoint = "token"
+	PriceAndAvailabilityEndpoint Endpoint = "priceandavailability"
+	OrderCreateEndpoint          Endpoint = "ordercreate"
+	OrderDetailEndpoint          Endpoint = "orderdetail"
+	WebhookSubscriptionsEndpoint Endpoint = "webhooksubscriptions"
+)
+
+// Fault describes a misbehaving response. Each injected fault is used for exactly one request.
+type Fault struct {
+	// Latency delays the response.
+	Latency time.Duration
+	// StatusCode replaces the response with an error status, e.g. 429 or 500.
+	StatusCode int
+	// RetryAfter is sent as Retry-After header along with StatusCode.
+	RetryAfter time.Duration
+	// MalformedJSON truncates the response body.
+	MalformedJSON bool
+}
+
+// Server is a fake Ingram API backed by fixtures.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	products      map[string]ingram.PriceAndAvailabilityResponse
+	orders        map[string]ingram.OrderDetailResponse
+	createdOrders []ingram.OrderCreateRequest
+	subscriptions []ingram.WebhookSubscription
+	faults        map[Endpoint][]Fault
+	requests      map[Endpoint]int
+}
+
+// NewServer starts a fake Ingram API. Close it once done.
+func NewServer() *Server {
+	s := &Server{
+		products: make(map[string]ingram.PriceAndAvailabilityResponse),
+		orders:   make(map[string]ingram.OrderDetailResponse),
+		faults:   make(map[Endpoint][]Fault),
+		requests: make(map[Endpoint]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/", s.serve(TokenEndpoint, s.token))
+	mux.HandleFunc("/resellers/v6/catalog/priceandavailability", s.serve(PriceAndAvailabilityEndpoint, s.authorized(s.priceAndAvailability)))
+	mux.HandleFunc("/resellers/v5/orders", s.serve(OrderCreateEndpoint, s.authorized(s.createOrder)))
+	mux.HandleFunc("/resellers/v5/orders/", s.serve(OrderDetailEndpoint, s.authorized(s.orderDetail)))
+	mux.HandleFunc("/resellers/v6/webhooks/subscriptions", s.serve(WebhookSubscriptionsEndpoint, s.authorized(s.webhookSubscriptions)))
+	mux.HandleFunc("/resellers/v6/webhooks/subscriptions/", s.serve(WebhookSubscriptionsEndpoint, s.authorized(s.webhookSubscription)))
+	s.Server = httptest.NewServer(stripSandbox(mux))
+
+	return s
+}
+
+// Client returns an Ingram client talking to s. Further options are applied after the fake's base URL and credentials.
+func (s *Server) Client(options ...ingram.OptionFunc) (*ingram.Ingram, error) {
+	return ingram.New(append([]ingram.OptionFunc{
+		ingram.WithBaseURL(s.URL),
+		ingram.WithOAuthCredentials(ClientID, ClientSecret),
+	}, options...)...)
+}
+
+// AddProduct adds a price and availability fixture, looked up by its Ingram part number or, for products
+// requested without one, by its vendor part number.
+func (s *Server) AddProduct(products ...ingram.PriceAndAvailabilityResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range products {
+		s.products[p.IngramPartNumber] = p
+	}
+}
+
+// AddOrder adds an order detail fixture, looked up by its order number.
+func (s *Server) AddOrder(orders ...ingram.OrderDetailResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range orders {
+		s.orders[o.OrderNumber] = o
+	}
+}
+
+// CreatedOrders returns all orders created so far.
+func (s *Server) CreatedOrders() []ingram.OrderCreateRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ingram.OrderCreateRequest(nil), s.createdOrders...)
+}
+
+// WebhookSubscriptions returns the current webhook subscriptions.
+func (s *Server) WebhookSubscriptions() []ingram.WebhookSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ingram.WebhookSubscription(nil), s.subscriptions...)
+}
+
+// InjectFault queues faults for the next requests to endpoint.
+func (s *Server) InjectFault(endpoint Endpoint, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[endpoint] = append(s.faults[endpoint], faults...)
+}
+
+// Requests returns the number of requests endpoint received.
+func (s *Server) Requests(endpoint Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[endpoint]
+}
+
+func stripSandbox(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/sandbox/") {
+			r.URL.Path = strings.TrimPrefix(r.URL.Path, "/sandbox")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) serve(endpoint Endpoint, handler func(r *http.Request) (interface{}, int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[endpoint]++
+		var fault Fault
+		if len(s.faults[endpoint]) > 0 {
+			fault = s.faults[endpoint][0]
+			s.faults[endpoint] = s.faults[endpoint][1:]
+		}
+		s.mu.Unlock()
+
+		// Ingram echoes the correlation ID of the request.
+		if id := r.Header.Get("IM-CorrelationID"); id != "" {
+			w.Header().Set("IM-CorrelationID", id)
+		}
+
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault.StatusCode != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+			}
+			writeError(w, fault.StatusCode, http.StatusText(fault.StatusCode))
+			return
+		}
+
+		response, status := handler(r)
+		b, err := json.Marshal(response)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if fault.MalformedJSON {
+			b = b[:len(b)/2]
+		}
+
+		if status == http.StatusNoContent {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(b)
+	}
+}
+
+func (s *Server) authorized(handler func(r *http.Request) (interface{}, int)) func(r *http.Request) (interface{}, int) {
+	return func(r *http.Request) (interface{}, int) {
+		if r.Header.Get("Authorization") != "Bearer "+AccessToken {
+			return errorResponse("invalid access token"), http.StatusUnauthorized
+		}
+		return handler(r)
+	}
+}
+
+func (s *Server) token(r *http.Request) (interface{}, int) {
+	if r.Method != http.MethodPost {
+		return errorResponse("method not allowed"), http.StatusMethodNotAllowed
+	}
+	err := r.ParseForm()
+	if err != nil {
+		return errorResponse(err.Error()), http.StatusBadRequest
+	}
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		return errorResponse("invalid client credentials"), http.StatusUnauthorized
+	}
+
+	return ingram.Token{
+		AccessToken: AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   "86399",
+	}, http.StatusOK
+}
+
+func (s *Server) priceAndAvailability(r *http.Request) (interface{}, int) {
+	if r.Method != http.MethodPost {
+		return errorResponse("method not allowed"), http.StatusMethodNotAllowed
+	}
+	if r.Header.Get("IM-CustomerNumber") == "" || r.Header.Get("IM-CountryCode") == "" {
+		return errorResponse("missing customer number or country code"), http.StatusBadRequest
+	}
+
+	var request struct {
+		Products []ingram.Product `json:"products"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return errorResponse(err.Error()), http.StatusBadRequest
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := make([]ingram.PriceAndAvailabilityResponse, 0, len(request.Products))
+	for _, p := range request.Products {
+		product, ok := s.product(p)
+		if !ok {
+			product = ingram.PriceAndAvailabilityResponse{
+				ProductStatusCode:    "E",
+				ProductStatusMessage: "Invalid SKU",
+				IngramPartNumber:     p.IngramPartNumber,
+				VendorPartNumber:     p.VendorPartNumber,
+			}
+		}
+		response = append(response, product)
+	}
+
+	return response, http.StatusOK
+}
+
+// product looks up p by its Ingram part number or, lacking one, by its vendor part number.
+func (s *Server) product(p ingram.Product) (ingram.PriceAndAvailabilityResponse, bool) {
+	if p.IngramPartNumber != "" {
+		product, ok := s.products[p.IngramPartNumber]
+		return product, ok
+	}
+	for _, product := range s.products {
+		if p.VendorPartNumber != "" && product.VendorPartNumber == p.VendorPartNumber {
+			return product, true
+		}
+	}
+	return ingram.PriceAndAvailabilityResponse{}, false
+}
+
+func (s *Server) createOrder(r *http.Request) (interface{}, int) {
+	if r.Method != http.MethodPost {
+		return errorResponse("method not allowed"), http.StatusMethodNotAllowed
+	}
+
+	var request struct {
+		OrderCreateRequest ingram.OrderCreateRequest `json:"ordercreaterequest"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return errorResponse(err.Error()), http.StatusBadRequest
+	}
+	order := request.OrderCreateRequest
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createdOrders = append(s.createdOrders, order)
+	orderNumber := fmt.Sprintf("40-%05d", len(s.createdOrders))
+
+	detail := ingram.OrderDetailResponse{
+		OrderNumber:         orderNumber,
+		CustomerOrderNumber: order.OrderCreateDetails.CustomerPurchaseOrderNumber,
+		OrderStatus:         "Processing",
+		EntryTimestamp:      ingram.Timestamp{Time: time.Now().UTC().Truncate(time.Second)},
+		CurrencyCode:        "EUR",
+	}
+	summary := ingram.OrderCreateResponse{
+		GlobalOrderID:            orderNumber,
+		InvoicingSystemOrderID:   orderNumber,
+		OrderType:                "S",
+		OrderTimestamp:           detail.EntryTimestamp,
+		NumberOfLinesWithSuccess: ingram.FlexInt(len(order.OrderCreateDetails.Lines)),
+	}
+	for n, line := range order.OrderCreateDetails.Lines {
+		lineNumber := strconv.Itoa(n + 1)
+		var price ingram.Decimal
+		if line.UnitPrice != nil {
+			price = *line.UnitPrice
+		} else if p, ok := s.products[line.IngramPartNumber]; ok {
+			price = p.Pricing.CustomerPrice
+		}
+		amount := price.Mul(ingram.NewDecimal(int64(line.Quantity), 0))
+
+		summary.OrderAmount = summary.OrderAmount.Add(amount)
+		summary.Lines = append(summary.Lines, ingram.OrderCreateResponseLine{
+			LineType:         string(ingram.Position),
+			GlobalLineNumber: lineNumber,
+			PartNumber:       line.IngramPartNumber,
+			LineNumber:       lineNumber,
+		})
+		detail.OrderSubTotal = detail.OrderSubTotal.Add(amount)
+		detail.Lines = append(detail.Lines, ingram.OrderDetailLine{
+			LineNumber:        lineNumber,
+			GlobalLineNumber:  lineNumber,
+			ERPOrderNumber:    orderNumber,
+			LineStatus:        "Processing",
+			PartNumber:        line.IngramPartNumber,
+			UnitPrice:         price,
+			ExtendedPrice:     amount,
+			RequestedQuantity: ingram.FlexInt(line.Quantity),
+			ConfirmedQuantity: ingram.FlexInt(line.Quantity),
+		})
+	}
+	detail.OrderTotalValue = detail.OrderSubTotal
+	s.orders[orderNumber] = detail
+
+	return ingram.OrderCreateResponseServiceResponse{
+		ServiceResponse: ingram.OrderServiceResponse{
+			ResponsePreamble: successPreamble(),
+			OrderSummary: ingram.OrderSummary{
+				OrderCreateResponses: []ingram.OrderCreateResponse{summary},
+			},
+		},
+	}, http.StatusOK
+}
+
+func (s *Server) orderDetail(r *http.Request) (interface{}, int) {
+	if r.Method != http.MethodGet {
+		return errorResponse("method not allowed"), http.StatusMethodNotAllowed
+	}
+	q := r.URL.Query()
+	if q.Get("customernumber") == "" || q.Get("isocountrycode") == "" {
+		return errorResponse("missing customer number or country code"), http.StatusBadRequest
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[strings.TrimPrefix(r.URL.Path, "/resellers/v5/orders/")]
+	if !ok {
+		return errorResponse("order not found"), http.StatusNotFound
+	}
+
+	return ingram.OrderDetailResponseServiceResponse{
+		ServiceResponse: ingram.OrderDetailServiceResponse{
+			ResponsePreamble:    successPreamble(),
+			OrderDetailResponse: order,
+		},
+	}, http.StatusOK
+}
+
+// webhookSubscriptions lists and creates subscriptions.
+func (s *Server) webhookSubscriptions(r *http.Request) (interface{}, int) {
+	if r.Header.Get("IM-CustomerNumber") == "" || r.Header.Get("IM-CountryCode") == "" {
+		return errorResponse("missing customer number or country code"), http.StatusBadRequest
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		return struct {
+			Subscriptions []ingram.WebhookSubscription `json:"subscriptions"`
+		}{Subscriptions: s.subscriptions}, http.StatusOK
+	case http.MethodPost:
+		var subscription ingram.WebhookSubscription
+		err := json.NewDecoder(r.Body).Decode(&subscription)
+		if err != nil {
+			return errorResponse(err.Error()), http.StatusBadRequest
+		}
+		subscription.SubscriptionID = fmt.Sprintf("sub-%d", len(s.subscriptions)+1)
+		subscription.Status = "ACTIVE"
+		s.subscriptions = append(s.subscriptions, subscription)
+		return subscription, http.StatusOK
+	default:
+		return errorResponse("method not allowed"), http.StatusMethodNotAllowed
+	}
+}
+
+// webhookSubscription updates and deletes a single subscription.
+func (s *Server) webhookSubscription(r *http.Request) (interface{}, int) {
+	if r.Header.Get("IM-CustomerNumber") == "" || r.Header.Get("IM-CountryCode") == "" {
+		return errorResponse("missing customer number or country code"), http.StatusBadRequest
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/resellers/v6/webhooks/subscriptions/")
+	n := -1
+	for k := range s.subscriptions {
+		if s.subscriptions[k].SubscriptionID == id {
+			n = k
+		}
+	}
+	if n == -1 {
+		return errorResponse("subscription not found"), http.StatusNotFound
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var subscription ingram.WebhookSubscription
+		err := json.NewDecoder(r.Body).Decode(&subscription)
+		if err != nil {
+			return errorResponse(err.Error()), http.StatusBadRequest
+		}
+		subscription.SubscriptionID = id
+		subscription.Status = s.subscriptions[n].Status
+		s.subscriptions[n] = subscription
+		return subscription, http.StatusOK
+	case http.MethodDelete:
+		s.subscriptions = append(s.subscriptions[:n], s.subscriptions[n+1:]...)
+		return nil, http.StatusNoContent
+	default:
+		return errorResponse("method not allowed"), http.StatusMethodNotAllowed
+	}
+}
+
+func successPreamble() ingram.ResponsePreamble {
+	return ingram.ResponsePreamble{
+		ResponseStatus:  "SUCCESS",
+		StatusCode:      "200",
+		ResponseMessage: "Data Found",
+	}
+}
+
+type errorBody struct {
+	Errors []errorMessage `json:"errors"`
+}
+
+type errorMessage struct {
+	Message string `json:"message"`
+}
+
+func errorResponse(message string) errorBody {
+	return errorBody{Errors: []errorMessage{{Message: message}}}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse(message))
+}
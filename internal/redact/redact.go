@@ -0,0 +1,95 @@
+// Package redact masks secrets in form encoded and JSON bodies. It is shared by the client's debug
+// logging and the cassette recorder, so both mask the same way.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Placeholder replaces masked values.
+const Placeholder = "REDACTED"
+
+// Secrets are the form fields and JSON keys holding credentials, tokens and webhook secret keys.
+var Secrets = []string{"client_id", "client_secret", "access_token", "secretkey"}
+
+// Fields is a set of lower-cased form fields, query parameters and JSON keys to mask.
+type Fields map[string]bool
+
+func NewFields(names ...string) Fields {
+	f := make(Fields)
+	f.Add(names...)
+	return f
+}
+
+// Add masks names, matched case-insensitively.
+func (f Fields) Add(names ...string) {
+	for _, name := range names {
+		f[strings.ToLower(name)] = true
+	}
+}
+
+// Values masks the fields in values in place and returns values.
+func (f Fields) Values(values url.Values) url.Values {
+	for k := range values {
+		if f[strings.ToLower(k)] {
+			values.Set(k, Placeholder)
+		}
+	}
+	return values
+}
+
+// Body masks the fields in form encoded and JSON bodies. Other bodies are returned unchanged.
+func (f Fields) Body(contentType string, body []byte) string {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		return f.Values(values).Encode()
+	}
+
+	v, ok := DecodeJSON(body)
+	if !ok {
+		return string(body)
+	}
+	b, err := json.Marshal(f.JSON(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(b)
+}
+
+// JSON masks the fields of a decoded JSON value in place at any depth and returns it.
+func (f Fields) JSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if f[strings.ToLower(k)] {
+				v[k] = Placeholder
+				continue
+			}
+			v[k] = f.JSON(value)
+		}
+	case []interface{}:
+		for n, value := range v {
+			v[n] = f.JSON(value)
+		}
+	}
+	return v
+}
+
+// DecodeJSON decodes a single JSON value keeping numbers as json.Number, so re-encoding doesn't alter them.
+func DecodeJSON(body []byte) (interface{}, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if dec.Decode(&v) != nil || dec.More() {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,26 @@
+package redact
+
+import "testing"
+
+func TestBody(t *testing.T) {
+	f := NewFields(Secrets...)
+	f.Add("Email")
+
+	tests := []struct {
+		contentType string
+		in          string
+		want        string
+	}{
+		{"application/x-www-form-urlencoded", "client_id=a&client_secret=b&grant_type=client_credentials", "client_id=REDACTED&client_secret=REDACTED&grant_type=client_credentials"},
+		{"application/json", `{"access_token":"t","expires_in":"86399"}`, `{"access_token":"REDACTED","expires_in":"86399"}`},
+		{"application/json", `{"subscriptions":[{"secretKey":"s","EMAIL":"a@b.c","amount":19.90}]}`, `{"subscriptions":[{"EMAIL":"REDACTED","amount":19.90,"secretKey":"REDACTED"}]}`},
+		{"text/plain", "client_secret=b", "client_secret=b"},
+		{"application/json", `{"a":1} {"b":2}`, `{"a":1} {"b":2}`},
+		{"application/json", "", ""},
+	}
+	for _, tt := range tests {
+		if got := f.Body(tt.contentType, []byte(tt.in)); got != tt.want {
+			t.Errorf("Body(%q, %s) = %s, want %s", tt.contentType, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package ingram
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+func (i *Ingram) logRequest(req *http.Request, res *http.Response, duration time.Duration, err error, reqBody, resBody []byte) {
+	if i.slogger == nil {
+		return
+	}
+
+	level := slog.LevelDebug
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", duration),
+	}
+	if id := req.Header.Get("IM-CorrelationID"); id != "" {
+		attrs = append(attrs, slog.String("correlation_id", id))
+	}
+
+	switch {
+	case err != nil:
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	case res != nil && res.StatusCode >= 500:
+		level = slog.LevelError
+	case res != nil && res.StatusCode >= 400:
+		level = slog.LevelWarn
+	}
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if id := res.Header.Get("IM-CorrelationID"); id != "" {
+			attrs = append(attrs, slog.String("response_correlation_id", id))
+		}
+	}
+
+	if i.logBodies {
+		if len(reqBody) > 0 {
+			attrs = append(attrs, slog.String("request_body", i.redactor.body(req.Header.Get("Content-Type"), reqBody)))
+		}
+		if res != nil && len(resBody) > 0 {
+			attrs = append(attrs, slog.String("response_body", i.redactor.body(res.Header.Get("Content-Type"), resBody)))
+		}
+	}
+
+	i.slogger.LogAttrs(req.Context(), level, "ingram request", attrs...)
+}
@@ -0,0 +1,143 @@
+package ingram_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+// logLines returns the lines of buf logged for path.
+func logLines(buf *bytes.Buffer, path string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.Contains(line, "path="+path+" ") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestSlog(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1"})
+	srv.InjectFault(ingramtest.PriceAndAvailabilityEndpoint, ingramtest.Fault{}, ingramtest.Fault{StatusCode: http.StatusNotFound}, ingramtest.Fault{StatusCode: http.StatusBadGateway})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := newTestClient(t, srv, ingram.WithSlog(logger))
+
+	request := &ingram.PriceAndAvailabilityRequest{IncludeAvailability: true, IncludePricing: true, IngramPartNumber: "SKU1"}
+	for n := 0; n < 3; n++ {
+		_, _ = client.PriceAndAvailability(context.Background(), request, ingram.CorrelationID("corr-1"))
+	}
+
+	lines := logLines(&buf, "/resellers/v6/catalog/priceandavailability")
+	want := []string{"level=DEBUG", "level=WARN", "level=ERROR"}
+	status := []string{"status=200", "status=404", "status=502"}
+	if len(lines) != len(want) {
+		t.Fatalf("logged %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for n, line := range lines {
+		for _, attr := range []string{want[n], status[n], `msg="ingram request"`, "method=POST", "correlation_id=corr-1", "response_correlation_id=corr-1", "duration="} {
+			if !strings.Contains(line, attr) {
+				t.Errorf("line %d lacks %s: %s", n, attr, line)
+			}
+		}
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestSlogTransportError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := ingram.New(
+		ingram.WithOAuthCredentials("id", "secret"),
+		ingram.WithHTTPClient(&http.Client{Transport: failingTransport{}}),
+		ingram.WithSlog(logger),
+		ingram.WithBodyLogging(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetOAuthToken(context.Background(), "id", "secret")
+	if err == nil {
+		t.Fatal("token request succeeded")
+	}
+
+	lines := logLines(&buf, "/oauth/oauth30/token")
+	if len(lines) != 1 {
+		t.Fatalf("logged %d lines, want 1:\n%s", len(lines), buf.String())
+	}
+	for _, attr := range []string{"level=ERROR", "connection refused"} {
+		if !strings.Contains(lines[0], attr) {
+			t.Errorf("line lacks %s: %s", attr, lines[0])
+		}
+	}
+	for _, attr := range []string{"status=", "response_body="} {
+		if strings.Contains(lines[0], attr) {
+			t.Errorf("line of a failed request has %s: %s", attr, lines[0])
+		}
+	}
+}
+
+type bufferLogger struct {
+	bytes.Buffer
+}
+
+func (l *bufferLogger) Printf(format string, v ...interface{}) {
+	fmt.Fprintf(&l.Buffer, format, v...)
+}
+
+func TestLogRedaction(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1", VendorPartNumber: "SECRET-VPN", Description: "100% recycled"})
+
+	var dump bufferLogger
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := newTestClient(t, srv,
+		ingram.WithLogger(&dump),
+		ingram.WithSlog(logger),
+		ingram.WithBodyLogging(),
+		ingram.WithRedactedFields("vendorPartNumber"),
+	)
+
+	_, err := client.PriceAndAvailability(context.Background(), &ingram.PriceAndAvailabilityRequest{IncludeAvailability: true, IncludePricing: true, IngramPartNumber: "SKU1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := map[string]string{"logger": dump.String(), "slog": buf.String()}
+	for name, output := range outputs {
+		for _, secret := range []string{ingramtest.ClientSecret, ingramtest.AccessToken, "SECRET-VPN"} {
+			if strings.Contains(output, secret) {
+				t.Errorf("%s output contains %s:\n%s", name, secret, output)
+			}
+		}
+		if !strings.Contains(output, "client_secret=REDACTED") {
+			t.Errorf("%s output lacks the redacted client secret:\n%s", name, output)
+		}
+		if !strings.Contains(output, "100% recycled") {
+			t.Errorf("%s output lacks the unredacted description:\n%s", name, output)
+		}
+	}
+	if !strings.Contains(dump.String(), "Authorization: REDACTED") {
+		t.Errorf("logger output lacks the redacted Authorization header:\n%s", dump.String())
+	}
+}
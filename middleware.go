@@ -0,0 +1,61 @@
+package ingram
+
+import (
+	"context"
+	"net/http"
+)
+
+// Call is a single API call passing through the middlewares.
+type Call struct {
+	// Operation is the name of the client method, e.g. "CreateOrderV5".
+	Operation string
+	// Input is the request struct passed to the client method, nil for token requests.
+	Input interface{}
+	// Request is the HTTP request about to be sent. Middlewares may modify it, e.g. to add headers.
+	Request *http.Request
+	// Response is set once the request was sent. Its body has been consumed.
+	Response *http.Response
+	// Output points to the value the response is decoded into, e.g. *OrderDetailResponseServiceResponse.
+	// It is populated once the next handler returned without error.
+	Output interface{}
+
+	options *callOptions
+}
+
+// Handler sends a call.
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware wraps every API call, including token requests.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middlewares to the client. The first middleware is the outermost.
+func WithMiddleware(middlewares ...Middleware) OptionFunc {
+	return func(i *Ingram) error {
+		i.middlewares = append(i.middlewares, middlewares...)
+		return nil
+	}
+}
+
+// BeforeRequest returns a middleware calling fn before a call is sent. An error aborts the call.
+func BeforeRequest(fn func(ctx context.Context, call *Call) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			err := fn(ctx, call)
+			if err != nil {
+				return err
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// AfterResponse returns a middleware calling fn after a call finished, with the error of the call.
+func AfterResponse(fn func(ctx context.Context, call *Call, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			err := next(ctx, call)
+			fn(ctx, call, err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ingram_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+func TestMiddleware(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1", Description: "Laptop"})
+
+	var order []string
+	var calls []*ingram.Call
+	var errs []error
+	client := newTestClient(t, srv, ingram.WithMiddleware(
+		func(next ingram.Handler) ingram.Handler {
+			return func(ctx context.Context, call *ingram.Call) error {
+				order = append(order, "outer")
+				return next(ctx, call)
+			}
+		},
+		ingram.BeforeRequest(func(_ context.Context, call *ingram.Call) error {
+			order = append(order, "before")
+			call.Request.Header.Set("X-Test", "1")
+			return nil
+		}),
+		ingram.AfterResponse(func(_ context.Context, call *ingram.Call, err error) {
+			calls = append(calls, call)
+			errs = append(errs, err)
+		}),
+	))
+
+	request := &ingram.PriceAndAvailabilityRequest{IncludeAvailability: true, IncludePricing: true, IngramPartNumber: "SKU1"}
+	_, err := client.PriceAndAvailability(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("middlewares saw %d calls, want the token and the lookup", len(calls))
+	}
+	if calls[0].Operation != "GetOAuthToken" || calls[0].Input != nil {
+		t.Errorf("first call = %s with input %v, want GetOAuthToken without input", calls[0].Operation, calls[0].Input)
+	}
+
+	call := calls[1]
+	if call.Operation != "PriceAndAvailability" {
+		t.Errorf("operation = %s, want PriceAndAvailability", call.Operation)
+	}
+	input, ok := call.Input.(*ingram.PriceAndAvailabilityRequest)
+	if !ok {
+		t.Fatalf("input is %T, want *PriceAndAvailabilityRequest", call.Input)
+	}
+	if input.IngramPartNumber != "SKU1" || input.CustomerNumber != "20-222222" {
+		t.Errorf("input = %+v, want SKU1 with the default customer number", input)
+	}
+	output, ok := call.Output.(*[]ingram.PriceAndAvailabilityResponse)
+	if !ok {
+		t.Fatalf("output is %T, want *[]PriceAndAvailabilityResponse", call.Output)
+	}
+	if len(*output) != 1 || (*output)[0].Description != "Laptop" {
+		t.Errorf("output = %+v", *output)
+	}
+	if call.Response == nil || call.Response.StatusCode != http.StatusOK {
+		t.Errorf("response = %v, want 200", call.Response)
+	}
+	if call.Request.Header.Get("X-Test") != "1" {
+		t.Error("header set by BeforeRequest is missing")
+	}
+	if errs[1] != nil {
+		t.Errorf("AfterResponse got %v", errs[1])
+	}
+	if want := "outer before outer before"; strings.Join(order, " ") != want {
+		t.Errorf("middlewares ran in order %v, want %s", order, want)
+	}
+}
+
+func TestBeforeRequestAborts(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+
+	errAbort := errors.New("abort")
+	client := newTestClient(t, srv, ingram.WithMiddleware(ingram.BeforeRequest(func(_ context.Context, call *ingram.Call) error {
+		if call.Operation == "OrderDetail" {
+			return errAbort
+		}
+		return nil
+	})))
+
+	_, err := client.OrderDetail(context.Background(), &ingram.OrderDetailRequest{OrderNumber: "40-1"})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("OrderDetail returned %v, want the middleware error", err)
+	}
+	if got := srv.Requests(ingramtest.OrderDetailEndpoint); got != 0 {
+		t.Errorf("aborted call sent %d requests", got)
+	}
+}
+
+func TestMiddlewareReentrantTokenRequest(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1"})
+
+	request := &ingram.PriceAndAvailabilityRequest{IncludeAvailability: true, IncludePricing: true, IngramPartNumber: "SKU1"}
+	var client *ingram.Ingram
+	nested := false
+	var nestedErr error
+	client = newTestClient(t, srv, ingram.WithMiddleware(ingram.BeforeRequest(func(ctx context.Context, call *ingram.Call) error {
+		// A middleware calling the client while the token is requested must not deadlock.
+		if call.Operation == "GetOAuthToken" && !nested {
+			nested = true
+			_, nestedErr = client.PriceAndAvailability(ctx, request)
+		}
+		return nil
+	})))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.PriceAndAvailability(context.Background(), request)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("token request deadlocked")
+	}
+	if nestedErr != nil {
+		t.Errorf("nested call: %v", nestedErr)
+	}
+}
@@ -0,0 +1,256 @@
+package ingram
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OrderImportColumns are the CSV columns understood by ReadOrdersCSV. Column names are case-insensitive,
+// customerPoNumber, quantity and one of ingramPartNumber or vendorPartNumber are required.
+var OrderImportColumns = []string{
+	"customerPoNumber", "attention", "addressLine1", "addressLine2", "addressLine3", "city", "state", "postalCode", "countryCode",
+	"ingramPartNumber", "vendorPartNumber", "quantity", "unitPrice",
+}
+
+type OrderImportResult struct {
+	Order    OrderCreateRequest
+	Response *OrderCreateResponseServiceResponse
+	Err      error
+}
+
+// ReadOrdersCSV reads one order line per row and groups the rows into orders by customer PO number.
+// The ship-to address is taken from the first row of each order. preamble is used for all orders.
+// Invalid rows don't stop reading: orders with invalid rows, and rows without a customer PO number,
+// are returned as failed results listing the row errors instead of as orders.
+func ReadOrdersCSV(r io.Reader, preamble RequestPreamble) ([]OrderCreateRequest, []OrderImportResult, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("missing csv header")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int)
+	for n, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = n
+	}
+	_, hasIngram := columns["ingrampartnumber"]
+	_, hasVendor := columns["vendorpartnumber"]
+	if _, ok := columns["customerponumber"]; !ok {
+		return nil, nil, errors.New("csv header needs a customerPoNumber column")
+	}
+	if _, ok := columns["quantity"]; !ok {
+		return nil, nil, errors.New("csv header needs a quantity column")
+	}
+	if !hasIngram && !hasVendor {
+		return nil, nil, errors.New("csv header needs an ingramPartNumber or vendorPartNumber column")
+	}
+
+	var orders []OrderCreateRequest
+	var failed []OrderImportResult
+	index := make(map[string]int)
+	rowErrs := make(map[int][]error)
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			failed = append(failed, OrderImportResult{Err: err})
+			continue
+		}
+		value := func(column string) string {
+			n, ok := columns[strings.ToLower(column)]
+			if !ok || n >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[n])
+		}
+
+		po := value("customerPoNumber")
+		if po == "" {
+			failed = append(failed, OrderImportResult{Err: fmt.Errorf("line %d: missing customer po number", line)})
+			continue
+		}
+		var errs []error
+		quantity, err := strconv.Atoi(value("quantity"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: invalid quantity: %w", line, err))
+		}
+		var unitPrice *Decimal
+		if v := value("unitPrice"); v != "" {
+			price, err := ParseDecimal(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d: invalid unit price: %w", line, err))
+			}
+			unitPrice = &price
+		}
+
+		n, ok := index[po]
+		if !ok {
+			order := OrderCreateRequest{
+				RequestPreamble: preamble,
+				OrderCreateDetails: OrderCreateDetails{
+					CustomerPurchaseOrderNumber: po,
+				},
+			}
+			if value("addressLine1") != "" {
+				order.OrderCreateDetails.ShipToAddress = &ShipToAddress{
+					Attention:    value("attention"),
+					AddressLine1: value("addressLine1"),
+					AddressLine2: value("addressLine2"),
+					AddressLine3: value("addressLine3"),
+					City:         value("city"),
+					State:        value("state"),
+					PostalCode:   value("postalCode"),
+					CountryCode:  value("countryCode"),
+				}
+			}
+
+			n = len(orders)
+			index[po] = n
+			orders = append(orders, order)
+		}
+		if len(errs) > 0 {
+			rowErrs[n] = append(rowErrs[n], errs...)
+			continue
+		}
+
+		details := &orders[n].OrderCreateDetails
+		details.Lines = append(details.Lines, Line{
+			LineType:         Position,
+			LineNumber:       fmt.Sprintf("%03d", len(details.Lines)+1),
+			IngramPartNumber: value("ingramPartNumber"),
+			VendorPartNumber: value("vendorPartNumber"),
+			Quantity:         quantity,
+			UnitPrice:        unitPrice,
+		})
+	}
+
+	valid := orders[:0]
+	for n, order := range orders {
+		if errs, ok := rowErrs[n]; ok {
+			failed = append(failed, OrderImportResult{Order: order, Err: errors.Join(errs...)})
+			continue
+		}
+		valid = append(valid, order)
+	}
+
+	return valid, failed, nil
+}
+
+// ReadOrdersJSON reads a single order or an array of orders. preamble fills in missing customer numbers and country codes.
+func ReadOrdersJSON(r io.Reader, preamble RequestPreamble) ([]OrderCreateRequest, error) {
+	var raw json.RawMessage
+	err := json.NewDecoder(r).Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []OrderCreateRequest
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		err = json.Unmarshal(raw, &orders)
+	} else {
+		orders = make([]OrderCreateRequest, 1)
+		err = json.Unmarshal(raw, &orders[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for n := range orders {
+		if orders[n].RequestPreamble.CustomerNumber == "" {
+			orders[n].RequestPreamble.CustomerNumber = preamble.CustomerNumber
+		}
+		if orders[n].RequestPreamble.ISOCountryCode == "" {
+			orders[n].RequestPreamble.ISOCountryCode = preamble.ISOCountryCode
+		}
+	}
+
+	return orders, nil
+}
+
+// ImportOrders validates and places orders one after another. With dryRun the orders are only validated,
+// after applying the client's customer number and country code like CreateOrderV5.
+// Failing orders don't stop the import, their error is part of the result.
+func (i *Ingram) ImportOrders(ctx context.Context, orders []OrderCreateRequest, dryRun bool) []OrderImportResult {
+	results := make([]OrderImportResult, len(orders))
+	for n := range orders {
+		results[n].Order = orders[n]
+
+		if ctx.Err() != nil {
+			results[n].Err = ctx.Err()
+			continue
+		}
+
+		if dryRun {
+			results[n].Order, results[n].Err = i.prepareOrderV5(i.newCallOptions(ctx, nil), &orders[n])
+			continue
+		}
+
+		results[n].Response, results[n].Err = i.CreateOrderV5(ctx, &orders[n])
+	}
+
+	return results
+}
+
+// WriteOrderImportReport writes results as CSV, one row per order.
+func WriteOrderImportReport(w io.Writer, results []OrderImportResult) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"customerPoNumber", "lines", "status", "globalOrderId", "linesWithSuccess", "linesWithError", "linesWithWarning", "orderAmount", "error"})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.Order.OrderCreateDetails.CustomerPurchaseOrderNumber,
+			strconv.Itoa(len(result.Order.OrderCreateDetails.Lines)),
+			"", "", "", "", "", "", "",
+		}
+
+		switch {
+		case result.Err != nil:
+			record[2] = "failed"
+			record[8] = result.Err.Error()
+		case result.Response == nil:
+			record[2] = "valid"
+		default:
+			record[2] = "created"
+			for _, o := range result.Response.ServiceResponse.OrderSummary.OrderCreateResponses {
+				record[3] = joinReportValue(record[3], o.GlobalOrderID)
+				record[4] = joinReportValue(record[4], o.NumberOfLinesWithSuccess.String())
+				record[5] = joinReportValue(record[5], o.NumberOfLinesWithError.String())
+				record[6] = joinReportValue(record[6], o.NumberOfLinesWithWarning.String())
+				record[7] = joinReportValue(record[7], o.OrderAmount.String())
+			}
+		}
+
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// joinReportValue joins the values of orders Ingram split into several.
+func joinReportValue(current, value string) string {
+	if current == "" {
+		return value
+	}
+	return current + " " + value
+}
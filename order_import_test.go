@@ -0,0 +1,133 @@
+package ingram_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+func TestReadOrdersCSV(t *testing.T) {
+	preamble := ingram.RequestPreamble{CustomerNumber: "20-222222", ISOCountryCode: "DE"}
+
+	tests := []struct {
+		name string
+		csv  string
+		// orders maps the customer PO numbers of the valid orders to their line count.
+		orders map[string]int
+		// failed lists the errors of the failed results.
+		failed  []string
+		wantErr string
+	}{
+		{
+			name:   "grouped by po",
+			csv:    "customerPoNumber,ingramPartNumber,quantity,unitPrice\nPO-1,SKU1,1,9.99\nPO-2,SKU2,2,\nPO-1,SKU3,3,1.50\n",
+			orders: map[string]int{"PO-1": 2, "PO-2": 1},
+		},
+		{
+			name:   "case-insensitive columns",
+			csv:    "CUSTOMERPONUMBER, VendorPartNumber ,Quantity\nPO-1,VPN1,1\n",
+			orders: map[string]int{"PO-1": 1},
+		},
+		{
+			name:   "invalid rows",
+			csv:    "customerPoNumber,ingramPartNumber,quantity,unitPrice\nPO-1,SKU1,x,\nPO-1,SKU2,1,\n,SKU3,1,\nPO-2,SKU4,1,abc\nPO-3,SKU5,1,\nPO-4,SKU6\n",
+			orders: map[string]int{"PO-3": 1},
+			failed: []string{
+				"line 4: missing customer po number",
+				"line 2: invalid quantity",
+				"line 5: invalid unit price",
+				"line 7: invalid quantity",
+			},
+		},
+		{name: "missing header", csv: "", wantErr: "missing csv header"},
+		{name: "missing po column", csv: "ingramPartNumber,quantity\n", wantErr: "customerPoNumber"},
+		{name: "missing quantity column", csv: "customerPoNumber,ingramPartNumber\n", wantErr: "quantity"},
+		{name: "missing part number column", csv: "customerPoNumber,quantity\n", wantErr: "ingramPartNumber or vendorPartNumber"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, failed, err := ingram.ReadOrdersCSV(strings.NewReader(tt.csv), preamble)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(orders) != len(tt.orders) {
+				t.Errorf("got %d orders, want %d", len(orders), len(tt.orders))
+			}
+			for _, order := range orders {
+				po := order.OrderCreateDetails.CustomerPurchaseOrderNumber
+				if got := len(order.OrderCreateDetails.Lines); got != tt.orders[po] {
+					t.Errorf("order %s has %d lines, want %d", po, got, tt.orders[po])
+				}
+				if order.RequestPreamble != preamble {
+					t.Errorf("order %s preamble = %+v", po, order.RequestPreamble)
+				}
+			}
+
+			if len(failed) != len(tt.failed) {
+				t.Fatalf("got %d failed results, want %d: %+v", len(failed), len(tt.failed), failed)
+			}
+			for n, result := range failed {
+				if result.Err == nil || !strings.Contains(result.Err.Error(), tt.failed[n]) {
+					t.Errorf("failed result %d error = %v, want %q", n, result.Err, tt.failed[n])
+				}
+			}
+		})
+	}
+}
+
+func TestReadOrdersCSVShipTo(t *testing.T) {
+	csv := "customerPoNumber,addressLine1,city,ingramPartNumber,quantity\nPO-1,Main St 1,Berlin,SKU1,1\nPO-1,Other St 2,Hamburg,SKU2,1\n"
+	orders, _, err := ingram.ReadOrdersCSV(strings.NewReader(csv), ingram.RequestPreamble{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders, want 1", len(orders))
+	}
+	details := orders[0].OrderCreateDetails
+	if details.ShipToAddress == nil || details.ShipToAddress.City != "Berlin" {
+		t.Errorf("ship-to address = %+v, want the one of the first row", details.ShipToAddress)
+	}
+	if details.Lines[0].LineNumber != "001" || details.Lines[1].LineNumber != "002" {
+		t.Errorf("line numbers = %s, %s", details.Lines[0].LineNumber, details.Lines[1].LineNumber)
+	}
+}
+
+func TestImportOrdersDryRun(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	client := newTestClient(t, srv)
+
+	csv := "customerPoNumber,ingramPartNumber,quantity\nPO-1,SKU1,1\nPO-TOO-LONG-FOR-INGRAM,SKU2,1\n"
+	orders, _, err := ingram.ReadOrdersCSV(strings.NewReader(csv), ingram.RequestPreamble{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := client.ImportOrders(context.Background(), orders, true)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Err != nil || results[0].Response != nil {
+		t.Errorf("first result = %+v, want a valid order", results[0])
+	}
+	if preamble := results[0].Order.RequestPreamble; preamble.CustomerNumber != "20-222222" || preamble.ISOCountryCode != "DE" {
+		t.Errorf("preamble = %+v, want the client defaults", preamble)
+	}
+	if results[1].Err == nil {
+		t.Error("order with a too long PO number is valid")
+	}
+	if n := srv.Requests(ingramtest.OrderCreateEndpoint); n != 0 {
+		t.Errorf("dry run sent %d orders", n)
+	}
+}
@@ -1,16 +1,10 @@
 package ingram
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
-	"time"
 )
 
 type LineType string
@@ -28,11 +22,39 @@ type OrderDetailRequest struct {
 
 type OrderDetailResponseServiceResponse struct {
 	ServiceResponse OrderDetailServiceResponse `json:"serviceresponse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderDetailResponseServiceResponse) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailResponseServiceResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderDetailResponseServiceResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailResponseServiceResponse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderDetailServiceResponse struct {
 	ResponsePreamble    ResponsePreamble    `json:"responsepreamble"`
 	OrderDetailResponse OrderDetailResponse `json:"orderdetailresponse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderDetailServiceResponse) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailServiceResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderDetailServiceResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailServiceResponse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderDetailResponse struct {
@@ -41,22 +63,42 @@ type OrderDetailResponse struct {
 	CustomerOrderNumber    string    `json:"customerordernumber"`
 	EndUserPoNumber        string    `json:"enduserponumber"`
 	OrderStatus            string    `json:"orderstatus"`
-	EntryTimestamp         time.Time `json:"entrytimestamp"`
+	EntryTimestamp         Timestamp `json:"entrytimestamp"`
 	EntryMethodDescription string    `json:"entrymethoddescription"`
-	OrderTotalValue        float64   `json:"ordertotalvalue"`
-	OrderSubTotal          float64   `json:"ordersubtotal"`
-	FreightAmount          string    `json:"freightamount"`
+	OrderTotalValue        Decimal   `json:"ordertotalvalue"`
+	OrderSubTotal          Decimal   `json:"ordersubtotal"`
+	FreightAmount          Decimal   `json:"freightamount"`
 	CurrencyCode           string    `json:"currencycode"`
-	TotalWeight            string    `json:"totalweight"`
-	TotalTax               string    `json:"totaltax"`
+	TotalWeight            Decimal   `json:"totalweight"`
+	TotalTax               Decimal   `json:"totaltax"`
 	BillToAddress          OrderDetailAddress
 	ShipToAddress          OrderDetailAddress
 	Lines                  []OrderDetailLine        `json:"lines"`
 	CommentLines           []OrderDetailCommentLine `json:"commentlines"`
 	MiscFeeLines           []OrderDetailMiscFeeLine `json:"miscfeeline"`
 	ExtendedSpecs          []ExtendedSpec           `json:"extendedspecs"`
+
+	// Extra holds the fields sent by Ingram without a struct field.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+func (r *OrderDetailResponse) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderDetailResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailResponse
+	return marshalExtra((*plain)(&r), r.Extra)
+}
+
+func (r *OrderDetailResponse) Total() Money    { return r.OrderTotalValue.In(r.CurrencyCode) }
+func (r *OrderDetailResponse) SubTotal() Money { return r.OrderSubTotal.In(r.CurrencyCode) }
+func (r *OrderDetailResponse) Freight() Money  { return r.FreightAmount.In(r.CurrencyCode) }
+func (r *OrderDetailResponse) Tax() Money      { return r.TotalTax.In(r.CurrencyCode) }
+
 type OrderDetailAddress struct {
 	Suffix       string `json:"suffix"`
 	Name         string `json:"name"`
@@ -68,6 +110,20 @@ type OrderDetailAddress struct {
 	State        string `json:"state"`
 	PostalCode   string `json:"postalcode"`
 	CountryCode  string `json:"countrycode"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderDetailAddress) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailAddress
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderDetailAddress) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailAddress
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderDetailLine struct {
@@ -82,80 +138,94 @@ type OrderDetailLine struct {
 	VendorCode             string  `json:"vendorcode"`
 	PartDescription1       string  `json:"partdescription1"`
 	PartDescription2       string  `json:"partdescription2"`
-	UnitWeight             string  `json:"unitweight"`
-	UnitPrice              float64 `json:"unitprice"`
-	ExtendedPrice          float64 `json:"extendedprice"`
-	TaxAmount              float64 `json:"taxamount"`
-	RequestedQuantity      string  `json:"requestedquantity"`
-	ConfirmedQuantity      string  `json:"confirmedquantity"`
-	BackorderQuantity      string  `json:"backorderquantity"`
+	UnitWeight             Decimal `json:"unitweight"`
+	UnitPrice              Decimal `json:"unitprice"`
+	ExtendedPrice          Decimal `json:"extendedprice"`
+	TaxAmount              Decimal `json:"taxamount"`
+	RequestedQuantity      FlexInt `json:"requestedquantity"`
+	ConfirmedQuantity      FlexInt `json:"confirmedquantity"`
+	BackorderQuantity      FlexInt `json:"backorderquantity"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderDetailLine) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailLine
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderDetailLine) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailLine
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderDetailCommentLine struct {
 	CommentText1 string `json:"commenttext1"`
 	CommentText2 string `json:"commenttext2"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderDetailCommentLine) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailCommentLine
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderDetailCommentLine) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailCommentLine
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderDetailMiscFeeLine struct {
-	Description  string `json:"description"`
-	ChargeAmount string `json:"chargeamount"`
+	Description  string  `json:"description"`
+	ChargeAmount Decimal `json:"chargeamount"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (i *Ingram) OrderDetail(ctx context.Context, orderDetail *OrderDetailRequest) (*OrderDetailResponseServiceResponse, error) {
-	err := i.validate.Struct(orderDetail)
-	if err != nil {
-		return nil, err
-	}
+func (r *OrderDetailMiscFeeLine) UnmarshalJSON(data []byte) error {
+	type plain OrderDetailMiscFeeLine
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
 
-	err = i.checkAndUpdateToken(ctx)
-	if err != nil {
-		return nil, err
-	}
+func (r OrderDetailMiscFeeLine) MarshalJSON() ([]byte, error) {
+	type plain OrderDetailMiscFeeLine
+	return marshalExtra((*plain)(&r), r.Extra)
+}
 
-	u, err := url.Parse(fmt.Sprintf("%s/resellers/v5/orders/%s", i.endpoint, orderDetail.OrderNumber))
+func (i *Ingram) OrderDetail(ctx context.Context, orderDetail *OrderDetailRequest, opts ...CallOption) (*OrderDetailResponseServiceResponse, error) {
+	o := i.newCallOptions(ctx, opts)
+	r := *orderDetail
+	orderDetail = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
+	err := i.validate.Struct(orderDetail)
 	if err != nil {
 		return nil, err
 	}
-	q := u.Query()
+
+	q := url.Values{}
 	q.Add("customernumber", orderDetail.CustomerNumber)
 	q.Add("isocountrycode", orderDetail.ISOCountryCode)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", i.token.AccessToken))
-	req.Header.Set("Accept", "*/*")
-
-	if i.logger != nil {
-		b, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
 
-	res, err := http.DefaultClient.Do(req)
+	req, err := i.newRequest(ctx, o, http.MethodGet, "/resellers/v5/orders/"+url.PathEscape(orderDetail.OrderNumber), q, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if i.logger != nil {
-		b, err := httputil.DumpResponse(res, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	if res.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(res.Body)
-		return nil, fmt.Errorf("%s: %s", res.Status, string(body))
-	}
-
 	var response OrderDetailResponseServiceResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
+	err = i.do(&Call{
+		Operation: "OrderDetail",
+		Input:     orderDetail,
+		Request:   req,
+		options:   o,
+		Output:    &response,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -211,8 +281,8 @@ type Line struct {
 	CustomerPartNumber   string         `json:"customerpartnumber,omitempty"`
 	UPCCode              string         `json:"UPCCode,omitempty"`
 	WareHouseID          string         `json:"warehouseid,omitempty"`
-	EndUserPrice         float64        `json:"enduserprice,omitempty"`
-	UnitPrice            float64        `json:"unitprice,omitempty"`
+	EndUserPrice         *Decimal       `json:"enduserprice,omitempty"`
+	UnitPrice            *Decimal       `json:"unitprice,omitempty"`
 	EndUser              *EndUser       `json:"enduser,omitempty"`
 	ProductExtendedSpecs []ExtendedSpec `json:"productextendedspecs,omitempty"`
 }
@@ -235,39 +305,123 @@ type EndUser struct {
 type ExtendedSpec struct {
 	AttributeName  string `json:"attributename"`
 	AttributeValue string `json:"attributevalue"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *ExtendedSpec) UnmarshalJSON(data []byte) error {
+	type plain ExtendedSpec
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r ExtendedSpec) MarshalJSON() ([]byte, error) {
+	type plain ExtendedSpec
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderCreateResponseServiceResponse struct {
 	ServiceResponse OrderServiceResponse `json:"serviceresponse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderCreateResponseServiceResponse) UnmarshalJSON(data []byte) error {
+	type plain OrderCreateResponseServiceResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderCreateResponseServiceResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderCreateResponseServiceResponse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderServiceResponse struct {
 	ResponsePreamble ResponsePreamble `json:"responsepreamble"`
 	OrderSummary     OrderSummary     `json:"ordersummary"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderServiceResponse) UnmarshalJSON(data []byte) error {
+	type plain OrderServiceResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderServiceResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderServiceResponse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type ResponsePreamble struct {
 	ResponseStatus  string `json:"responsestatus"`
 	StatusCode      string `json:"statuscode"`
 	ResponseMessage string `json:"responsemessage"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *ResponsePreamble) UnmarshalJSON(data []byte) error {
+	type plain ResponsePreamble
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r ResponsePreamble) MarshalJSON() ([]byte, error) {
+	type plain ResponsePreamble
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderSummary struct {
 	OrderCreateResponses []OrderCreateResponse `json:"ordercreateresponse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderSummary) UnmarshalJSON(data []byte) error {
+	type plain OrderSummary
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderSummary) MarshalJSON() ([]byte, error) {
+	type plain OrderSummary
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderCreateResponse struct {
-	NumberOfLinesWithSuccess string                    `json:"numberoflineswithsuccess"`
-	NumberOfLinesWithError   string                    `json:"numberoflineswitherror"`
-	NumberOfLinesWithWarning string                    `json:"numberoflineswithwarning"`
+	NumberOfLinesWithSuccess FlexInt                   `json:"numberoflineswithsuccess"`
+	NumberOfLinesWithError   FlexInt                   `json:"numberoflineswitherror"`
+	NumberOfLinesWithWarning FlexInt                   `json:"numberoflineswithwarning"`
 	GlobalOrderID            string                    `json:"globalorderid"`
 	OrderType                string                    `json:"ordertype"`
-	OrderTimestamp           string                    `json:"ordertimestamp"`
+	OrderTimestamp           Timestamp                 `json:"ordertimestamp"`
 	InvoicingSystemOrderID   string                    `json:"invoicingsystemorderid"`
-	TaxAmount                float64                   `json:"taxamount"`
-	FreightAmount            float64                   `json:"freightamount"`
-	OrderAmount              float64                   `json:"orderamount"`
+	TaxAmount                Decimal                   `json:"taxamount"`
+	FreightAmount            Decimal                   `json:"freightamount"`
+	OrderAmount              Decimal                   `json:"orderamount"`
 	Lines                    []OrderCreateResponseLine `json:"lines"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *OrderCreateResponse) UnmarshalJSON(data []byte) error {
+	type plain OrderCreateResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderCreateResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderCreateResponse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type OrderCreateResponseLine struct {
@@ -276,65 +430,55 @@ type OrderCreateResponseLine struct {
 	PartNumber       string `json:"partnumber"`
 	GlobalSKUID      string `json:"globalskuid"`
 	LineNumber       string `json:"linenumber"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (i *Ingram) CreateOrderV5(ctx context.Context, order *OrderCreateRequest) (*OrderCreateResponseServiceResponse, error) {
-	err := i.validate.Struct(order)
-	if err != nil {
-		return nil, err
-	}
+func (r *OrderCreateResponseLine) UnmarshalJSON(data []byte) error {
+	type plain OrderCreateResponseLine
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r OrderCreateResponseLine) MarshalJSON() ([]byte, error) {
+	type plain OrderCreateResponseLine
+	return marshalExtra((*plain)(&r), r.Extra)
+}
 
-	err = i.checkAndUpdateToken(ctx)
+func (i *Ingram) CreateOrderV5(ctx context.Context, order *OrderCreateRequest, opts ...CallOption) (*OrderCreateResponseServiceResponse, error) {
+	o := i.newCallOptions(ctx, opts)
+	r, err := i.prepareOrderV5(o, order)
 	if err != nil {
 		return nil, err
 	}
+	order = &r
 
-	b := new(bytes.Buffer)
-	err = json.NewEncoder(b).Encode(createOrderV5{
+	req, err := i.newRequest(ctx, o, http.MethodPost, "/resellers/v5/orders", nil, createOrderV5{
 		OrderCreateRequest: *order,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.endpoint+"/resellers/v5/orders", b)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", i.token.AccessToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "*/*")
-
-	if i.logger != nil {
-		b, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if i.logger != nil {
-		b, err := httputil.DumpResponse(res, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
-	}
-
 	var response OrderCreateResponseServiceResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
+	err = i.do(&Call{
+		Operation: "CreateOrderV5",
+		Input:     order,
+		Request:   req,
+		options:   o,
+		Output:    &response,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
+
+// prepareOrderV5 returns a copy of order with the customer defaults of o applied and validates it.
+func (i *Ingram) prepareOrderV5(o *callOptions, order *OrderCreateRequest) (OrderCreateRequest, error) {
+	r := *order
+	o.customer(&r.RequestPreamble.CustomerNumber, &r.RequestPreamble.ISOCountryCode)
+	return r, i.validate.Struct(&r)
+}
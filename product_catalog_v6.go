@@ -1,17 +1,11 @@
 package ingram
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
-
-	uuid "github.com/google/uuid"
 )
 
 type PriceAndAvailabilityRequest struct {
@@ -22,6 +16,17 @@ type PriceAndAvailabilityRequest struct {
 	IngramPartNumber    string `validate:"required"`
 }
 
+// MaxPriceAndAvailabilityProducts is the maximum number of products Ingram accepts per price and availability request.
+const MaxPriceAndAvailabilityProducts = 50
+
+type PriceAndAvailabilityBatchRequest struct {
+	IncludeAvailability bool
+	IncludePricing      bool
+	CustomerNumber      string    `validate:"required"`
+	ISOCountryCode      string    `validate:"required"`
+	Products            []Product `validate:"required,min=1,max=50"`
+}
+
 type PriceAndAvailabilityResponse struct {
 	ProductStatusCode         string       `json:"productStatusCode"`
 	ProductStatusMessage      string       `json:"productStatusMessage"`
@@ -45,12 +50,41 @@ type PriceAndAvailabilityResponse struct {
 	GovtEndUserType           string       `json:"govtEndUserType"`
 	Availability              Availability `json:"availability"`
 	Pricing                   Pricing      `json:"pricing"`
+
+	// Extra holds the fields sent by Ingram without a struct field.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *PriceAndAvailabilityResponse) UnmarshalJSON(data []byte) error {
+	type plain PriceAndAvailabilityResponse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r PriceAndAvailabilityResponse) MarshalJSON() ([]byte, error) {
+	type plain PriceAndAvailabilityResponse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type Availability struct {
 	Available               bool                      `json:"available"`
 	TotalAvailability       int64                     `json:"totalAvailability"`
 	AvailabilityByWarehouse []AvailabilityByWarehouse `json:"availabilityByWarehouse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *Availability) UnmarshalJSON(data []byte) error {
+	type plain Availability
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r Availability) MarshalJSON() ([]byte, error) {
+	type plain Availability
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type AvailabilityByWarehouse struct {
@@ -59,17 +93,49 @@ type AvailabilityByWarehouse struct {
 	QuantityAvailable      int64  `json:"quantityAvailable"`
 	QuantityBackordered    int64  `json:"quantityBackordered"`
 	QuantityBackorderedEta string `json:"quantityBackorderedEta"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *AvailabilityByWarehouse) UnmarshalJSON(data []byte) error {
+	type plain AvailabilityByWarehouse
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r AvailabilityByWarehouse) MarshalJSON() ([]byte, error) {
+	type plain AvailabilityByWarehouse
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type Pricing struct {
 	CurrencyCode               string  `json:"currencyCode"`
-	RetailPrice                float64 `json:"retailPrice"`
-	MapPrice                   float64 `json:"mapPrice"`
-	CustomerPrice              float64 `json:"customerPrice"`
+	RetailPrice                Decimal `json:"retailPrice"`
+	MapPrice                   Decimal `json:"mapPrice"`
+	CustomerPrice              Decimal `json:"customerPrice"`
 	SpecialBidPricingAvailable bool    `json:"specialBidPricingAvailable"`
 	WebDiscountsAvailable      bool    `json:"webDiscountsAvailable"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *Pricing) UnmarshalJSON(data []byte) error {
+	type plain Pricing
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r Pricing) MarshalJSON() ([]byte, error) {
+	type plain Pricing
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
+func (r *Pricing) Retail() Money   { return r.RetailPrice.In(r.CurrencyCode) }
+func (r *Pricing) MAP() Money      { return r.MapPrice.In(r.CurrencyCode) }
+func (r *Pricing) Customer() Money { return r.CustomerPrice.In(r.CurrencyCode) }
+
 type Product struct {
 	IngramPartNumber     string                `json:"ingramPartNumber,omitempty"`
 	VendorPartNumber     string                `json:"vendorPartNumber,omitempty"`
@@ -84,76 +150,61 @@ type AdditionalAttribute struct {
 	AttributeValue string `json:"attributeValue"`
 }
 
-func (i *Ingram) PriceAndAvailability(ctx context.Context, priceAndAvailabilityRequest *PriceAndAvailabilityRequest) ([]PriceAndAvailabilityResponse, error) {
+// PriceAndAvailability looks up a single product. An empty customer number or country code is taken
+// from the call options or the client defaults.
+func (i *Ingram) PriceAndAvailability(ctx context.Context, priceAndAvailabilityRequest *PriceAndAvailabilityRequest, opts ...CallOption) ([]PriceAndAvailabilityResponse, error) {
+	o := i.newCallOptions(ctx, opts)
+	r := *priceAndAvailabilityRequest
+	priceAndAvailabilityRequest = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
 	err := i.validate.Struct(priceAndAvailabilityRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	err = i.checkAndUpdateToken(ctx)
-	if err != nil {
-		return nil, err
-	}
+	return i.priceAndAvailability(ctx, o, "PriceAndAvailability", priceAndAvailabilityRequest,
+		r.IncludeAvailability, r.IncludePricing, []Product{{IngramPartNumber: r.IngramPartNumber}})
+}
 
-	u, err := url.Parse(fmt.Sprintf("%s/resellers/v6/catalog/priceandavailability", i.endpoint))
+// PriceAndAvailabilityBatch looks up to MaxPriceAndAvailabilityProducts products in one request.
+// Products unknown to Ingram are reported through their ProductStatusCode instead of an error.
+func (i *Ingram) PriceAndAvailabilityBatch(ctx context.Context, priceAndAvailabilityRequest *PriceAndAvailabilityBatchRequest, opts ...CallOption) ([]PriceAndAvailabilityResponse, error) {
+	o := i.newCallOptions(ctx, opts)
+	r := *priceAndAvailabilityRequest
+	priceAndAvailabilityRequest = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
+	err := i.validate.Struct(priceAndAvailabilityRequest)
 	if err != nil {
 		return nil, err
 	}
-	q := u.Query()
-	q.Add("includeAvailability", strconv.FormatBool(priceAndAvailabilityRequest.IncludeAvailability))
-	q.Add("includePricing", strconv.FormatBool(priceAndAvailabilityRequest.IncludePricing))
-	u.RawQuery = q.Encode()
 
-	b := new(bytes.Buffer)
-	err = json.NewEncoder(b).Encode(struct {
+	return i.priceAndAvailability(ctx, o, "PriceAndAvailabilityBatch", priceAndAvailabilityRequest,
+		r.IncludeAvailability, r.IncludePricing, r.Products)
+}
+
+// priceAndAvailability sends a validated price and availability request, input is passed to the middlewares.
+func (i *Ingram) priceAndAvailability(ctx context.Context, o *callOptions, operation string, input interface{}, includeAvailability, includePricing bool, products []Product) ([]PriceAndAvailabilityResponse, error) {
+	q := url.Values{}
+	q.Add("includeAvailability", strconv.FormatBool(includeAvailability))
+	q.Add("includePricing", strconv.FormatBool(includePricing))
+
+	req, err := i.newRequest(ctx, o, http.MethodPost, "/resellers/v6/catalog/priceandavailability", q, struct {
 		Products []Product `json:"products"`
 	}{
-		Products: []Product{{
-			IngramPartNumber: priceAndAvailabilityRequest.IngramPartNumber,
-		}},
+		Products: products,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), b)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", i.token.AccessToken))
-	req.Header.Set("Accept", "*/*")
-	req.Header.Add("IM-CustomerNumber", priceAndAvailabilityRequest.CustomerNumber)
-	req.Header.Add("IM-CountryCode", priceAndAvailabilityRequest.ISOCountryCode)
-	req.Header.Add("IM-CorrelationID", uuid.NewString()) // some random uuid to trick ingram
-
-	if i.logger != nil {
-		b, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if i.logger != nil {
-		b, err := httputil.DumpResponse(res, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	if res.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(res.Body)
-		return nil, fmt.Errorf("%s: %s", res.Status, string(body))
-	}
-
 	var response []PriceAndAvailabilityResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
+	err = i.do(&Call{
+		Operation: operation,
+		Input:     input,
+		Request:   req,
+		options:   o,
+		Output:    &response,
+	})
 	if err != nil {
 		return nil, err
 	}
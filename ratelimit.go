@@ -0,0 +1,84 @@
+package ingram
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter delays requests before they are sent. A limiter may be shared by several clients using
+// the same OAuth app, as Ingram rate limits per app.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter waits for limiter before every request, including token requests.
+func WithRateLimiter(limiter RateLimiter) OptionFunc {
+	return func(i *Ingram) error {
+		i.rateLimiter = limiter
+		return nil
+	}
+}
+
+// NewRateLimiter returns a token bucket allowing perSecond requests per second on average and bursts
+// of up to burst requests. Waiting requests are served in order.
+func NewRateLimiter(perSecond float64, burst int) RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	// Reserve a token, going into debt if none is left. The debt is paid off by waiting.
+	b.tokens--
+	tokens := b.tokens
+	b.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+	if b.rate <= 0 {
+		b.cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(time.Duration(-tokens / b.rate * float64(time.Second)))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// cancel returns a reserved token.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
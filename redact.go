@@ -0,0 +1,91 @@
+package ingram
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/enthus-golang/ingram/internal/redact"
+)
+
+const redacted = redact.Placeholder
+
+// redactedHeaders are masked in debug dumps.
+var redactedHeaders = []string{"Authorization"}
+
+// redactor masks secrets in everything the client logs.
+type redactor struct {
+	fields redact.Fields
+}
+
+func newRedactor() *redactor {
+	return &redactor{fields: redact.NewFields(redact.Secrets...)}
+}
+
+// add masks fields, matched case-insensitively against form fields and JSON keys at any depth.
+func (r *redactor) add(fields ...string) {
+	r.fields.Add(fields...)
+}
+
+// dumpRequest works like httputil.DumpRequest, with secrets masked.
+func (r *redactor) dumpRequest(req *http.Request) ([]byte, error) {
+	clone := req.Clone(req.Context())
+	clone.Header = r.header(req.Header)
+	clone.Body = nil
+
+	b, err := httputil.DumpRequest(clone, false)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetBody == nil {
+		return b, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, r.body(req.Header.Get("Content-Type"), data)...), nil
+}
+
+// dumpResponse works like httputil.DumpResponse, with secrets masked. The body of res is restored.
+func (r *redactor) dumpResponse(res *http.Response) ([]byte, error) {
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+
+	clone := *res
+	clone.Header = r.header(res.Header)
+	clone.Body = nil
+	b, err := httputil.DumpResponse(&clone, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, r.body(res.Header.Get("Content-Type"), data)...), nil
+}
+
+func (r *redactor) header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range redactedHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, redacted)
+		}
+	}
+	return h
+}
+
+// body masks the redacted fields in form encoded and JSON bodies. Other bodies are returned unchanged.
+func (r *redactor) body(contentType string, body []byte) string {
+	return r.fields.Body(contentType, body)
+}
@@ -0,0 +1,172 @@
+package ingram
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrUnknownTenant is returned by Registry lookups without a matching profile.
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// Profile is an Ingram account of a Registry.
+type Profile struct {
+	// Tenant is the key calls are routed by, e.g. "de" or a shop ID.
+	Tenant         string `validate:"required"`
+	ClientID       string `validate:"required"`
+	ClientSecret   string `validate:"required"`
+	CustomerNumber string `validate:"required"`
+	CountryCode    string `validate:"required"`
+	SenderID       string
+	Sandbox        bool
+	// Options are applied after the options of the registry, e.g. WithBaseURL for a regional gateway.
+	Options []OptionFunc
+}
+
+// Registry holds a client per profile, so several customer numbers, countries and OAuth apps can be
+// used side by side. Every client caches its own token. Clients share the options of the registry,
+// including the HTTP client and so its transport, and one rate limiter per OAuth app.
+type Registry struct {
+	options    []OptionFunc
+	newLimiter func() RateLimiter
+	validate   *validator.Validate
+
+	mu        sync.RWMutex
+	tenants   map[string]*Ingram
+	countries map[string]*Ingram
+	// limiters are keyed by client ID only, profiles of one OAuth app share the limiter regardless of
+	// their customer number and country.
+	limiters map[string]RateLimiter
+}
+
+type RegistryOptionFunc func(r *Registry) error
+
+// WithClientOptions applies options to the clients of all profiles, e.g. WithHTTPClient or WithSlog.
+func WithClientOptions(options ...OptionFunc) RegistryOptionFunc {
+	return func(r *Registry) error {
+		r.options = append(r.options, options...)
+		return nil
+	}
+}
+
+// WithAppRateLimit limits the requests per OAuth app, shared by all profiles with the same ClientID, as
+// Ingram enforces its rate limits per app rather than per customer number or country. See NewRateLimiter.
+func WithAppRateLimit(perSecond float64, burst int) RegistryOptionFunc {
+	return func(r *Registry) error {
+		r.newLimiter = func() RateLimiter {
+			return NewRateLimiter(perSecond, burst)
+		}
+		return nil
+	}
+}
+
+func NewRegistry(options ...RegistryOptionFunc) (*Registry, error) {
+	r := &Registry{
+		tenants:   make(map[string]*Ingram),
+		countries: make(map[string]*Ingram),
+		limiters:  make(map[string]RateLimiter),
+		validate:  validator.New(),
+	}
+
+	for _, v := range options {
+		err := v(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Add creates the client of profile. Tenant keys must be unique, the first profile added for a
+// country is used by Country.
+func (r *Registry) Add(profile Profile) error {
+	err := r.validate.Struct(profile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tenants[profile.Tenant]; ok {
+		return fmt.Errorf("duplicate tenant %q", profile.Tenant)
+	}
+
+	options := append([]OptionFunc{}, r.options...)
+	options = append(options,
+		WithOAuthCredentials(profile.ClientID, profile.ClientSecret),
+		WithCustomerNumber(profile.CustomerNumber),
+		WithCountryCode(profile.CountryCode),
+	)
+	if profile.SenderID != "" {
+		options = append(options, WithSenderID(profile.SenderID))
+	}
+	if profile.Sandbox {
+		options = append(options, EnableSandbox())
+	}
+	if r.newLimiter != nil {
+		limiter, ok := r.limiters[profile.ClientID]
+		if !ok {
+			limiter = r.newLimiter()
+			r.limiters[profile.ClientID] = limiter
+		}
+		options = append(options, WithRateLimiter(limiter))
+	}
+	options = append(options, profile.Options...)
+
+	i, err := New(options...)
+	if err != nil {
+		return fmt.Errorf("tenant %s: %w", profile.Tenant, err)
+	}
+
+	r.tenants[profile.Tenant] = i
+	country := strings.ToUpper(profile.CountryCode)
+	if _, ok := r.countries[country]; !ok {
+		r.countries[country] = i
+	}
+
+	return nil
+}
+
+// Tenant returns the client of the profile with the tenant key.
+func (r *Registry) Tenant(tenant string) (*Ingram, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.tenants[tenant]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTenant, tenant)
+	}
+	return i, nil
+}
+
+// Country returns the client of the first profile added for the ISO country code.
+func (r *Registry) Country(countryCode string) (*Ingram, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.countries[strings.ToUpper(countryCode)]
+	if !ok {
+		return nil, fmt.Errorf("%w for country %q", ErrUnknownTenant, countryCode)
+	}
+	return i, nil
+}
+
+// Tenants returns the sorted tenant keys.
+func (r *Registry) Tenants() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenants := make([]string, 0, len(r.tenants))
+	for tenant := range r.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	return tenants
+}
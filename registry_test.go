@@ -0,0 +1,86 @@
+package ingram
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestRegistry(t *testing.T, profiles ...Profile) *Registry {
+	t.Helper()
+
+	r, err := NewRegistry(WithAppRateLimit(10, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, profile := range profiles {
+		err = r.Add(profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+func TestRegistry(t *testing.T) {
+	r := newTestRegistry(t,
+		Profile{Tenant: "de", ClientID: "app1", ClientSecret: "s", CustomerNumber: "20-111111", CountryCode: "DE"},
+		Profile{Tenant: "de-b2b", ClientID: "app1", ClientSecret: "s", CustomerNumber: "20-222222", CountryCode: "DE"},
+		Profile{Tenant: "at", ClientID: "app2", ClientSecret: "s", CustomerNumber: "30-111111", CountryCode: "AT"},
+	)
+
+	de, err := r.Tenant("de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if de.customerNumber != "20-111111" || de.countryCode != "DE" {
+		t.Errorf("tenant de has customer number %s and country %s", de.customerNumber, de.countryCode)
+	}
+
+	country, err := r.Country("de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if country != de {
+		t.Error("Country(de) is not the first profile added for DE")
+	}
+
+	if got := r.Tenants(); len(got) != 3 || got[0] != "at" || got[1] != "de" || got[2] != "de-b2b" {
+		t.Errorf("tenants = %v", got)
+	}
+
+	_, err = r.Tenant("fr")
+	if !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Tenant(fr) = %v, want ErrUnknownTenant", err)
+	}
+	_, err = r.Country("FR")
+	if !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Country(FR) = %v, want ErrUnknownTenant", err)
+	}
+
+	err = r.Add(Profile{Tenant: "de", ClientID: "app3", ClientSecret: "s", CustomerNumber: "20-333333", CountryCode: "DE"})
+	if err == nil {
+		t.Error("adding a duplicate tenant succeeded")
+	}
+	err = r.Add(Profile{Tenant: "ch", ClientID: "app3", ClientSecret: "s", CountryCode: "CH"})
+	if err == nil {
+		t.Error("adding a profile without customer number succeeded")
+	}
+}
+
+func TestRegistryLimiters(t *testing.T) {
+	r := newTestRegistry(t,
+		Profile{Tenant: "de", ClientID: "app1", ClientSecret: "s", CustomerNumber: "20-111111", CountryCode: "DE"},
+		Profile{Tenant: "at", ClientID: "app1", ClientSecret: "s", CustomerNumber: "30-111111", CountryCode: "AT"},
+		Profile{Tenant: "ch", ClientID: "app2", ClientSecret: "s", CustomerNumber: "40-111111", CountryCode: "CH"},
+	)
+
+	de, _ := r.Tenant("de")
+	at, _ := r.Tenant("at")
+	ch, _ := r.Tenant("ch")
+	if de.rateLimiter == nil || de.rateLimiter != at.rateLimiter {
+		t.Error("profiles of the same OAuth app don't share the rate limiter")
+	}
+	if ch.rateLimiter == de.rateLimiter {
+		t.Error("profiles of different OAuth apps share the rate limiter")
+	}
+}
@@ -0,0 +1,213 @@
+package ingram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// newRequest creates an authorized request against the API endpoint. A non-nil body is encoded as JSON.
+func (i *Ingram) newRequest(ctx context.Context, o *callOptions, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	token, err := i.checkAndUpdateToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(i.endpoint + path)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		q := u.Query()
+		for k, v := range query {
+			q[k] = append(q[k], v...)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		b := new(bytes.Buffer)
+		err = json.NewEncoder(b).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+		r = b
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("IM-CorrelationID", o.correlationID)
+	if o.customerNumber != "" {
+		req.Header.Set("IM-CustomerNumber", o.customerNumber)
+	}
+	if o.countryCode != "" {
+		req.Header.Set("IM-CountryCode", o.countryCode)
+	}
+	if o.senderID != "" {
+		req.Header.Set("IM-SenderID", o.senderID)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// Do calls an endpoint without a dedicated method, e.g. a new v6 resource. path is relative to the API
+// endpoint, like "/resellers/v6/quotes/search", and may carry a query string that query is merged into.
+// A non-nil body is encoded as JSON, a successful response is decoded into out unless it is nil. Like all other calls, the request is authorized, carries the
+// default headers and passes the middlewares, logging, rate limiting and retries. Non-2xx responses
+// return an *APIError.
+func (i *Ingram) Do(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...CallOption) error {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	o := i.newCallOptions(ctx, opts)
+	req, err := i.newRequest(ctx, o, method, path, query, body)
+	if err != nil {
+		return err
+	}
+
+	return i.do(&Call{
+		Operation: "Do",
+		Input:     body,
+		Request:   req,
+		options:   o,
+		Output:    out,
+	})
+}
+
+// do passes call through the middlewares and sends it.
+func (i *Ingram) do(call *Call) error {
+	return i.handler(call.Request.Context(), call)
+}
+
+// send sends the request of call and decodes a successful response into call.Output, which may be nil.
+// Errors other than *APIError are returned as *RequestError.
+func (i *Ingram) send(ctx context.Context, call *Call) (err error) {
+	defer func() {
+		err = requestError(call, err)
+	}()
+
+	if i.rateLimiter != nil {
+		start := time.Now()
+		err = i.rateLimiter.Wait(ctx)
+		i.telemetry.rateLimiterWaited(ctx, call.Operation, time.Since(start))
+		if err != nil {
+			return err
+		}
+	}
+
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+
+	req := call.Request.WithContext(ctx)
+	var res *http.Response
+	start := time.Now()
+	defer func() {
+		call.Response = res
+		i.telemetry.request(ctx, call.Operation, res, time.Since(start))
+	}()
+
+	if i.logger != nil {
+		b, err := i.redactor.dumpRequest(req)
+		if err != nil {
+			return err
+		}
+		i.logger.Printf("%s", b)
+	}
+
+	var reqBody []byte
+	if i.slogger != nil && i.logBodies && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		reqBody, err = io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err = i.httpClient.Do(req)
+	if err != nil {
+		i.logRequest(req, nil, time.Since(start), err, reqBody, nil)
+		return err
+	}
+	defer res.Body.Close()
+
+	if i.logger != nil {
+		b, err := i.redactor.dumpResponse(res)
+		if err != nil {
+			return err
+		}
+		i.logger.Printf("%s", b)
+	}
+
+	if i.slogger != nil {
+		var resBody []byte
+		if i.logBodies {
+			resBody, err = io.ReadAll(res.Body)
+			if err != nil {
+				return err
+			}
+			res.Body = io.NopCloser(bytes.NewReader(resBody))
+		}
+		i.logRequest(req, res, time.Since(start), nil, reqBody, resBody)
+	}
+
+	responseCorrelationID := res.Header.Get("IM-CorrelationID")
+	if call.options != nil && call.options.responseCorrelationID != nil {
+		*call.options.responseCorrelationID = responseCorrelationID
+	}
+
+	if call.options != nil && call.options.rawResponse != nil {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		*call.options.rawResponse = body
+		res.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(res.Body)
+		return &APIError{
+			StatusCode:            res.StatusCode,
+			Status:                res.Status,
+			Body:                  body,
+			CorrelationID:         req.Header.Get("IM-CorrelationID"),
+			ResponseCorrelationID: responseCorrelationID,
+		}
+	}
+
+	if call.Output == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	err = json.NewDecoder(res.Body).Decode(call.Output)
+	if err != nil {
+		return err
+	}
+	if i.timestampLocation != nil {
+		localizeTimestamps(reflect.ValueOf(call.Output), i.timestampLocation)
+	}
+
+	return nil
+}
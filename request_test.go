@@ -0,0 +1,122 @@
+package ingram_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+)
+
+// newDoServer returns a client whose token requests succeed and whose other requests are served by handler.
+func newDoServer(t *testing.T, handler http.HandlerFunc) *ingram.Ingram {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(ingram.Token{AccessToken: "token", TokenType: "Bearer", ExpiresIn: "86399"})
+	})
+	mux.HandleFunc("/", handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := ingram.New(
+		ingram.WithBaseURL(srv.URL),
+		ingram.WithOAuthCredentials("id", "secret"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestDo(t *testing.T) {
+	var got *http.Request
+	client := newDoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		_, _ = w.Write([]byte(`{"quoteNumber":"Q1"}`))
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		path      string
+		query     url.Values
+		wantPath  string
+		wantQuery string
+	}{
+		{name: "leading slash", path: "/resellers/v6/quotes/search", wantPath: "/resellers/v6/quotes/search"},
+		{name: "no leading slash", path: "resellers/v6/quotes/search", wantPath: "/resellers/v6/quotes/search"},
+		{name: "query", path: "resellers/v6/quotes/search", query: url.Values{"pageSize": {"10"}}, wantPath: "/resellers/v6/quotes/search", wantQuery: "pageSize=10"},
+		{name: "query in path", path: "resellers/v6/quotes/search?pageNumber=2", query: url.Values{"pageSize": {"10"}}, wantPath: "/resellers/v6/quotes/search", wantQuery: "pageNumber=2&pageSize=10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out struct {
+				QuoteNumber string `json:"quoteNumber"`
+			}
+			err := client.Do(ctx, http.MethodGet, tt.path, tt.query, nil, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.URL.Path != tt.wantPath {
+				t.Errorf("path = %s, want %s", got.URL.Path, tt.wantPath)
+			}
+			if got.URL.RawQuery != tt.wantQuery {
+				t.Errorf("query = %s, want %s", got.URL.RawQuery, tt.wantQuery)
+			}
+			if out.QuoteNumber != "Q1" {
+				t.Errorf("quote number = %q, want Q1", out.QuoteNumber)
+			}
+		})
+	}
+}
+
+func TestDoWithoutOutput(t *testing.T) {
+	status := http.StatusOK
+	client := newDoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status != http.StatusNoContent {
+			_, _ = w.Write([]byte(`{"ignored":true}`))
+		}
+	})
+	ctx := context.Background()
+
+	err := client.Do(ctx, http.MethodDelete, "/resellers/v6/quotes/Q1", nil, nil, nil)
+	if err != nil {
+		t.Errorf("nil output: %v", err)
+	}
+
+	status = http.StatusNoContent
+	out := map[string]interface{}{"kept": true}
+	err = client.Do(ctx, http.MethodDelete, "/resellers/v6/quotes/Q1", nil, nil, &out)
+	if err != nil {
+		t.Errorf("no content: %v", err)
+	}
+	if len(out) != 1 || out["kept"] != true {
+		t.Errorf("output = %v, want it untouched", out)
+	}
+}
+
+func TestDoAPIError(t *testing.T) {
+	client := newDoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"quote not found"}]}`))
+	})
+
+	err := client.Do(context.Background(), http.MethodGet, "/resellers/v6/quotes/Q1", nil, nil, nil)
+	var apiErr *ingram.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want *ingram.APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("status code = %d, want 404", apiErr.StatusCode)
+	}
+	if string(apiErr.Body) != `{"errors":[{"message":"quote not found"}]}` {
+		t.Errorf("body = %s", apiErr.Body)
+	}
+}
@@ -0,0 +1,109 @@
+package ingram
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WithRetry retries failed requests up to attempts times in total, waiting backoff before the first
+// retry and doubling it for every further one. A Retry-After header of Ingram takes precedence.
+//
+// Rate limited (429) and unavailable (503) responses are retried for all requests. Network errors and
+// other 5xx responses are only retried for GET, PUT and DELETE requests, so orders aren't placed twice.
+func WithRetry(attempts int, backoff time.Duration) OptionFunc {
+	return func(i *Ingram) error {
+		i.retryAttempts = attempts
+		i.retryBackoff = backoff
+		return nil
+	}
+}
+
+// WithTimeout limits the duration of every request attempt, including reading the response.
+func WithTimeout(timeout time.Duration) OptionFunc {
+	return func(i *Ingram) error {
+		i.timeout = timeout
+		return nil
+	}
+}
+
+// sendWithRetry sends call, retrying it as configured by WithRetry. It returns the number of retries.
+func (i *Ingram) sendWithRetry(ctx context.Context, call *Call) (int, error) {
+	backoff := i.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := i.send(ctx, call)
+		if err == nil || attempt >= i.retryAttempts || !retryable(call, err) || ctx.Err() != nil {
+			return attempt - 1, err
+		}
+
+		wait := backoff
+		if d, ok := retryAfter(call.Response); ok {
+			wait = d
+		}
+		backoff *= 2
+
+		if call.Request.GetBody != nil {
+			call.Request.Body, err = call.Request.GetBody()
+			if err != nil {
+				return attempt - 1, requestError(call, err)
+			}
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return attempt - 1, requestError(call, ctx.Err())
+		}
+	}
+}
+
+func retryable(call *Call, err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+			return idempotent(call.Request.Method)
+		}
+		return false
+	}
+	// The response of a successful request couldn't be read.
+	if call.Response != nil {
+		return false
+	}
+
+	return idempotent(call.Request.Method)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay of the Retry-After header of res in seconds or as HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,43 @@
+package ingram
+
+import (
+	"context"
+	"net/url"
+)
+
+// TokenService requests OAuth tokens.
+type TokenService interface {
+	GetOAuthToken(ctx context.Context, clientID, clientSecret string) (*Token, error)
+}
+
+// CatalogService looks up product prices and availability.
+type CatalogService interface {
+	PriceAndAvailability(ctx context.Context, priceAndAvailabilityRequest *PriceAndAvailabilityRequest, opts ...CallOption) ([]PriceAndAvailabilityResponse, error)
+	PriceAndAvailabilityBatch(ctx context.Context, priceAndAvailabilityRequest *PriceAndAvailabilityBatchRequest, opts ...CallOption) ([]PriceAndAvailabilityResponse, error)
+}
+
+// OrderService creates and looks up orders.
+type OrderService interface {
+	OrderDetail(ctx context.Context, orderDetail *OrderDetailRequest, opts ...CallOption) (*OrderDetailResponseServiceResponse, error)
+	CreateOrderV5(ctx context.Context, order *OrderCreateRequest, opts ...CallOption) (*OrderCreateResponseServiceResponse, error)
+}
+
+// WebhookSubscriptionService manages webhook subscriptions.
+type WebhookSubscriptionService interface {
+	CreateWebhookSubscription(ctx context.Context, subscription *WebhookSubscriptionRequest, opts ...CallOption) (*WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, subscriptions *WebhookSubscriptionsRequest, opts ...CallOption) ([]WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, subscription *WebhookSubscriptionRequest, opts ...CallOption) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscription *DeleteWebhookSubscriptionRequest, opts ...CallOption) error
+}
+
+// Client combines all services and Do, so code can depend on it instead of *Ingram. Package ingrammock provides fakes.
+type Client interface {
+	TokenService
+	CatalogService
+	OrderService
+	WebhookSubscriptionService
+
+	Do(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...CallOption) error
+}
+
+var _ Client = (*Ingram)(nil)
@@ -0,0 +1,149 @@
+package ingram
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/enthus-golang/ingram"
+
+// telemetry creates a span per API call, including its retries, and records metrics per request.
+// Without configured providers it is a no-op.
+type telemetry struct {
+	tracer         trace.Tracer
+	duration       metric.Float64Histogram
+	tokenRefreshes metric.Int64Counter
+	rateLimited    metric.Int64Counter
+	rateLimitWait  metric.Float64Histogram
+}
+
+// WithTracerProvider creates a client span for every API call, named after the client method. Retries
+// are part of the span, their number is recorded as ingram.retry_count.
+func WithTracerProvider(provider trace.TracerProvider) OptionFunc {
+	return func(i *Ingram) error {
+		i.tracerProvider = provider
+		return nil
+	}
+}
+
+// WithMeterProvider records the request duration, token refreshes, rate limited responses and the
+// time spent waiting for the RateLimiter.
+func WithMeterProvider(provider metric.MeterProvider) OptionFunc {
+	return func(i *Ingram) error {
+		i.meterProvider = provider
+		return nil
+	}
+}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*telemetry, error) {
+	if tracerProvider == nil {
+		tracerProvider = tracenoop.NewTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+
+	t := &telemetry{
+		tracer: tracerProvider.Tracer(instrumentationName),
+	}
+	meter := meterProvider.Meter(instrumentationName)
+
+	var err error
+	t.duration, err = meter.Float64Histogram("ingram.client.request.duration",
+		metric.WithDescription("Duration of Ingram API requests."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	t.tokenRefreshes, err = meter.Int64Counter("ingram.client.token.refreshes",
+		metric.WithDescription("Number of OAuth tokens requested."))
+	if err != nil {
+		return nil, err
+	}
+	t.rateLimited, err = meter.Int64Counter("ingram.client.rate_limited",
+		metric.WithDescription("Number of requests rejected with 429 Too Many Requests."))
+	if err != nil {
+		return nil, err
+	}
+	t.rateLimitWait, err = meter.Float64Histogram("ingram.client.rate_limit.wait",
+		metric.WithDescription("Time requests waited for the client side rate limiter."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// start starts the span of operation as child of the span in ctx.
+func (t *telemetry) start(ctx context.Context, operation string, req *http.Request) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("ingram.operation", operation),
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.URL.Path),
+	}
+	if customerNumber := requestCustomerNumber(req); customerNumber != "" {
+		attrs = append(attrs, attribute.String("ingram.customer_number", customerNumber))
+	}
+	if id := req.Header.Get("IM-CorrelationID"); id != "" {
+		attrs = append(attrs, attribute.String("ingram.correlation_id", id))
+	}
+
+	return t.tracer.Start(ctx, "ingram."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// end finishes the span of a call with the response of its last attempt.
+func (t *telemetry) end(span trace.Span, res *http.Response, err error, retries int) {
+	span.SetAttributes(attribute.Int("ingram.retry_count", retries))
+	if res != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// request records the metrics of a single request attempt.
+func (t *telemetry) request(ctx context.Context, operation string, res *http.Response, duration time.Duration) {
+	attrs := []attribute.KeyValue{attribute.String("ingram.operation", operation)}
+	if res != nil {
+		attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+		if res.StatusCode == http.StatusTooManyRequests {
+			t.rateLimited.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+	}
+	t.duration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+}
+
+func (t *telemetry) rateLimiterWaited(ctx context.Context, operation string, duration time.Duration) {
+	t.rateLimitWait.Record(ctx, duration.Seconds(), metric.WithAttributes(attribute.String("ingram.operation", operation)))
+}
+
+func (t *telemetry) tokenRefreshed(ctx context.Context) {
+	t.tokenRefreshes.Add(ctx, 1)
+}
+
+// sendTraced sends call, including its retries, within one span.
+func (i *Ingram) sendTraced(ctx context.Context, call *Call) error {
+	ctx, span := i.telemetry.start(ctx, call.Operation, call.Request)
+	retries, err := i.sendWithRetry(ctx, call)
+	i.telemetry.end(span, call.Response, err, retries)
+	return err
+}
+
+func requestCustomerNumber(req *http.Request) string {
+	if customerNumber := req.Header.Get("IM-CustomerNumber"); customerNumber != "" {
+		return customerNumber
+	}
+	return req.URL.Query().Get("customernumber")
+}
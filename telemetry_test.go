@@ -0,0 +1,108 @@
+package ingram_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+)
+
+func TestTelemetry(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(ingram.PriceAndAvailabilityResponse{IngramPartNumber: "SKU1"})
+	srv.InjectFault(ingramtest.PriceAndAvailabilityEndpoint, ingramtest.Fault{StatusCode: 429})
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	client, err := srv.Client(
+		ingram.WithCustomerNumber("123"),
+		ingram.WithCountryCode("DE"),
+		ingram.WithRetry(3, time.Millisecond),
+		ingram.WithRateLimiter(ingram.NewRateLimiter(1000, 10)),
+		ingram.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		ingram.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.PriceAndAvailability(context.Background(), &ingram.PriceAndAvailabilityRequest{
+		IncludeAvailability: true,
+		IncludePricing:      true,
+		IngramPartNumber:    "SKU1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want one for the token and one for the call", len(ended))
+	}
+	for _, span := range ended {
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		switch span.Name() {
+		case "ingram.GetOAuthToken":
+			if got := attrs["ingram.retry_count"].AsInt64(); got != 0 {
+				t.Errorf("token span retry count = %d, want 0", got)
+			}
+		case "ingram.PriceAndAvailability":
+			if got := attrs["ingram.retry_count"].AsInt64(); got != 1 {
+				t.Errorf("retry count = %d, want 1", got)
+			}
+			if got := attrs["http.response.status_code"].AsInt64(); got != 200 {
+				t.Errorf("status code = %d, want 200", got)
+			}
+		default:
+			t.Errorf("unexpected span %s", span.Name())
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	if got := histogramCount(metrics["ingram.client.request.duration"]); got != 3 {
+		t.Errorf("recorded %d request durations, want 3", got)
+	}
+	if got := histogramCount(metrics["ingram.client.rate_limit.wait"]); got != 3 {
+		t.Errorf("recorded %d rate limiter waits, want 3", got)
+	}
+	rateLimited, _ := metrics["ingram.client.rate_limited"].(metricdata.Sum[int64])
+	if len(rateLimited.DataPoints) != 1 || rateLimited.DataPoints[0].Value != 1 {
+		t.Errorf("rate limited = %+v, want 1", rateLimited.DataPoints)
+	}
+	tokenRefreshes, _ := metrics["ingram.client.token.refreshes"].(metricdata.Sum[int64])
+	if len(tokenRefreshes.DataPoints) != 1 || tokenRefreshes.DataPoints[0].Value != 1 {
+		t.Errorf("token refreshes = %+v, want 1", tokenRefreshes.DataPoints)
+	}
+}
+
+func histogramCount(data metricdata.Aggregation) uint64 {
+	h, _ := data.(metricdata.Histogram[float64])
+	var count uint64
+	for _, dp := range h.DataPoints {
+		count += dp.Count
+	}
+	return count
+}
@@ -0,0 +1,129 @@
+package ingram
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// timestampLocation holds the location set by SetTimestampLocation, nil for UTC.
+var timestampLocation atomic.Pointer[time.Location]
+
+// SetTimestampLocation sets the location of timestamps Ingram sends without zone, UTC by default. It
+// applies to all clients and webhooks decoded afterwards, WithTimestampLocation overrides it per client.
+func SetTimestampLocation(loc *time.Location) {
+	timestampLocation.Store(loc)
+}
+
+func defaultTimestampLocation() *time.Location {
+	if loc := timestampLocation.Load(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// timestampLayouts are the formats Ingram uses, with zone first.
+var timestampLayouts = []struct {
+	layout string
+	zoned  bool
+}{
+	{time.RFC3339Nano, true},
+	{"2006-01-02T15:04:05.999999999Z0700", true},
+	{"2006-01-02 15:04:05.999999999Z07:00", true},
+	{"2006-01-02 15:04:05.999999999Z0700", true},
+	{"2006-01-02T15:04:05.999999999", false},
+	{"2006-01-02 15:04:05.999999999", false},
+	{"2006-01-02T15:04", false},
+	{"2006-01-02", false},
+}
+
+// Timestamp is a date or point in time decoded from any of the formats Ingram emits: RFC 3339, with or
+// without zone, with a space instead of the T and date only. Timestamps without zone are in the
+// location set by SetTimestampLocation or WithTimestampLocation. Empty strings and null decode as the
+// zero time, which is encoded as null. Timestamps are always encoded as RFC 3339, so a date-only value
+// like "2024-05-01" is encoded as "2024-05-01T00:00:00Z".
+type Timestamp struct {
+	time.Time
+	// floating is set for timestamps sent without zone.
+	floating bool
+}
+
+// ParseTimestamp parses s in one of the formats accepted by Timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+
+	for _, l := range timestampLayouts {
+		var t time.Time
+		var err error
+		if l.zoned {
+			t, err = time.Parse(l.layout, s)
+		} else {
+			t, err = time.ParseInLocation(l.layout, s, defaultTimestampLocation())
+		}
+		if err == nil {
+			return Timestamp{Time: t, floating: !l.zoned}, nil
+		}
+	}
+
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return t.Time.MarshalJSON()
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	s, err := jsonScalar(b)
+	if err != nil {
+		return err
+	}
+
+	*t, err = ParseTimestamp(s)
+	return err
+}
+
+// InLocation returns t with the same date and clock in loc if it was sent without zone. Timestamps
+// with zone are returned unchanged.
+func (t Timestamp) InLocation(loc *time.Location) Timestamp {
+	if !t.floating || loc == nil {
+		return t
+	}
+
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+	return Timestamp{Time: time.Date(year, month, day, hour, minute, sec, t.Nanosecond(), loc), floating: true}
+}
+
+var timestampType = reflect.TypeOf(Timestamp{})
+
+// localizeTimestamps moves the timestamps without zone reachable through the exported fields of v to loc.
+func localizeTimestamps(v reflect.Value, loc *time.Location) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			localizeTimestamps(v.Elem(), loc)
+		}
+	case reflect.Slice, reflect.Array:
+		for n := 0; n < v.Len(); n++ {
+			localizeTimestamps(v.Index(n), loc)
+		}
+	case reflect.Struct:
+		if v.Type() == timestampType {
+			if v.CanSet() {
+				v.Set(reflect.ValueOf(v.Interface().(Timestamp).InLocation(loc)))
+			}
+			return
+		}
+		for n := 0; n < v.NumField(); n++ {
+			if v.Type().Field(n).IsExported() {
+				localizeTimestamps(v.Field(n), loc)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ingram
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimestampLayouts(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	tests := []struct {
+		in   string
+		want time.Time
+		json string
+	}{
+		{`"2024-05-01T10:20:30.5+02:00"`, time.Date(2024, 5, 1, 10, 20, 30, 5e8, berlin), `"2024-05-01T10:20:30.5+02:00"`},
+		{`"2024-05-01T10:20:30Z"`, time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), `"2024-05-01T10:20:30Z"`},
+		{`"2024-05-01T10:20:30+0200"`, time.Date(2024, 5, 1, 10, 20, 30, 0, berlin), `"2024-05-01T10:20:30+02:00"`},
+		{`"2024-05-01 10:20:30+02:00"`, time.Date(2024, 5, 1, 10, 20, 30, 0, berlin), `"2024-05-01T10:20:30+02:00"`},
+		{`"2024-05-01 10:20:30.123+0200"`, time.Date(2024, 5, 1, 10, 20, 30, 123e6, berlin), `"2024-05-01T10:20:30.123+02:00"`},
+		{`"2024-05-01T10:20:30.123"`, time.Date(2024, 5, 1, 10, 20, 30, 123e6, time.UTC), `"2024-05-01T10:20:30.123Z"`},
+		{`"2024-05-01 10:20:30"`, time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), `"2024-05-01T10:20:30Z"`},
+		{`"2024-05-01T10:20"`, time.Date(2024, 5, 1, 10, 20, 0, 0, time.UTC), `"2024-05-01T10:20:00Z"`},
+		// Date-only values are encoded as full RFC 3339 timestamps.
+		{`"2024-05-01"`, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), `"2024-05-01T00:00:00Z"`},
+		{`""`, time.Time{}, `null`},
+		{`null`, time.Time{}, `null`},
+	}
+	if len(tests)-3 != len(timestampLayouts) {
+		t.Fatalf("%d layouts tested, timestampLayouts has %d", len(tests)-3, len(timestampLayouts))
+	}
+
+	for _, tt := range tests {
+		var ts Timestamp
+		err := json.Unmarshal([]byte(tt.in), &ts)
+		if err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, ts.Time, tt.want)
+		}
+		b, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("Marshal(%s): %v", tt.in, err)
+			continue
+		}
+		if string(b) != tt.json {
+			t.Errorf("Marshal(%s) = %s, want %s", tt.in, b, tt.json)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"01.05.2024"`), &ts); err == nil {
+		t.Error("Unmarshal of an unknown layout succeeded")
+	}
+}
+
+func TestTimestampInLocation(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	floating, err := ParseTimestamp("2024-05-01 10:20:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 5, 1, 10, 20, 30, 0, berlin)
+	if got := floating.InLocation(berlin); !got.Equal(want) {
+		t.Errorf("InLocation = %v, want %v", got.Time, want)
+	}
+
+	zoned, err := ParseTimestamp("2024-05-01T10:20:30Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := zoned.InLocation(berlin); !got.Equal(zoned.Time) {
+		t.Errorf("InLocation moved a zoned timestamp to %v", got.Time)
+	}
+
+	res := &OrderDetailServiceResponse{}
+	res.OrderDetailResponse.EntryTimestamp = floating
+	localizeTimestamps(reflect.ValueOf(res), berlin)
+	if got := res.OrderDetailResponse.EntryTimestamp; !got.Equal(want) {
+		t.Errorf("localizeTimestamps = %v, want %v", got.Time, want)
+	}
+}
+
+func TestSetTimestampLocation(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	SetTimestampLocation(berlin)
+	defer SetTimestampLocation(nil)
+
+	ts, err := ParseTimestamp("2024-05-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 5, 1, 0, 0, 0, 0, berlin); !ts.Equal(want) {
+		t.Errorf("ParseTimestamp = %v, want %v", ts.Time, want)
+	}
+}
@@ -3,10 +3,9 @@ package ingram
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -17,73 +16,78 @@ type Token struct {
 	TokenType   string    `json:"token_type"`
 	ExpiresIn   string    `json:"expires_in"`
 	ValidUntil  time.Time `json:"-"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *Token) UnmarshalJSON(data []byte) error {
+	type plain Token
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r Token) MarshalJSON() ([]byte, error) {
+	type plain Token
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 func (i *Ingram) GetOAuthToken(ctx context.Context, clientID, clientSecret string) (*Token, error) {
-	data := fmt.Sprintf(`grant_type=client_credentials&client_id=%s&client_secret=%s`, clientID, clientSecret)
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	data.Set("client_id", clientID)
+	data.Set("client_secret", clientSecret)
 
-	version := "30"
-	if i.isSandbox {
-		version = "20"
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/oauth/oauth%s/token", apiEndpoint, version), strings.NewReader(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.tokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "*/*")
-
-	if i.logger != nil {
-		b, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if i.logger != nil {
-		b, err := httputil.DumpResponse(res, true)
-		if err != nil {
-			return nil, err
-		}
-		i.logger.Printf(string(b))
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, errors.New("unable to create token")
-	}
+	o := i.newCallOptions(ctx, nil)
+	req.Header.Set("IM-CorrelationID", o.correlationID)
 
 	var t Token
-	err = json.NewDecoder(res.Body).Decode(&t)
+	err = i.do(&Call{
+		Operation: "GetOAuthToken",
+		Request:   req,
+		Output:    &t,
+		options:   o,
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to create token: %w", err)
 	}
 
 	return &t, nil
 }
 
-func (i *Ingram) checkAndUpdateToken(ctx context.Context) error {
-	if i.token != nil && time.Now().Before(i.token.ValidUntil) {
-		return nil
+// checkAndUpdateToken returns the cached token, requesting a new one shortly before it expires.
+// The token is requested without holding tokenMu, as the request passes the middlewares, which
+// may call the client again. Concurrent callers may each request a token, the last one is kept.
+func (i *Ingram) checkAndUpdateToken(ctx context.Context) (*Token, error) {
+	i.tokenMu.Lock()
+	token := i.token
+	i.tokenMu.Unlock()
+
+	if token != nil && time.Now().Before(token.ValidUntil) {
+		return token, nil
 	}
 
 	token, err := i.GetOAuthToken(ctx, i.clientID, i.clientSecret)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	expiresIn, err := strconv.Atoi(token.ExpiresIn)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	token.ValidUntil = time.Now().Add(time.Duration(expiresIn-60) * time.Second)
+	i.telemetry.tokenRefreshed(ctx)
 
+	i.tokenMu.Lock()
 	i.token = token
+	i.tokenMu.Unlock()
 
-	return nil
+	return token, nil
 }
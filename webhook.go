@@ -1,68 +1,357 @@
 package ingram
 
-import "time"
+import (
+	"encoding/json"
+	"strings"
+)
 
+type WebhookTopic string
 type WebhookEvent string
 type WebhookEventType string
 type WebhookLineStatus string
 
 const (
+	OrdersTopic WebhookTopic = "resellers/orders"
+	StockTopic  WebhookTopic = "resellers/stock"
+	PriceTopic  WebhookTopic = "resellers/price"
+
 	UpdateEvent WebhookEvent = "im::updated"
 
 	OrderShipped  WebhookEventType = "IM::order_shipped"
 	OrderInvoiced WebhookEventType = "IM::order_invoiced"
 	OrderHold     WebhookEventType = "IM::order_hold"
 	OrderVoided   WebhookEventType = "IM::order_voided"
+	StockUpdate   WebhookEventType = "IM::stock_update"
+	PriceUpdate   WebhookEventType = "IM::price_update"
 
 	LineShipped    WebhookLineStatus = "IM::SHIPPED"
 	LineSalesHold  WebhookLineStatus = "IM::SALES_HOLD"
 	LineOnlineHold WebhookLineStatus = "IM::IM_ONLINE_HOLD"
 )
 
+// WebhookPayload is implemented by all webhook resources.
+type WebhookPayload interface {
+	Type() WebhookEventType
+}
+
 type Webhook struct {
+	Topic          string    `json:"topic"`
+	Event          string    `json:"event"`
+	EventTimeStamp Timestamp `json:"eventTimeStamp"`
+	EventID        string    `json:"eventId"`
+	// Resource holds *WebhookResource, *WebhookStockResource, *WebhookPriceResource or
+	// *WebhookUnknownResource depending on the event type and topic.
+	Resource WebhookPayload `json:"resource"`
+	// RawResource holds the undecoded resource as delivered by Ingram.
+	RawResource json.RawMessage `json:"-"`
+	// Extra holds the fields of the envelope without a struct field.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+type webhookEnvelope struct {
 	Topic          string          `json:"topic"`
 	Event          string          `json:"event"`
-	EventTimeStamp time.Time       `json:"eventTimeStamp"`
+	EventTimeStamp Timestamp       `json:"eventTimeStamp"`
 	EventID        string          `json:"eventId"`
-	Resource       WebhookResource `json:"resource"`
+	Resource       json.RawMessage `json:"resource"`
+}
+
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	var envelope webhookEnvelope
+	extra, err := unmarshalExtra(data, &envelope)
+	if err != nil {
+		return err
+	}
+
+	*w = Webhook{
+		Topic:          envelope.Topic,
+		Event:          envelope.Event,
+		EventTimeStamp: envelope.EventTimeStamp,
+		EventID:        envelope.EventID,
+		RawResource:    envelope.Resource,
+		Extra:          extra,
+	}
+	if len(envelope.Resource) == 0 || string(envelope.Resource) == "null" {
+		return nil
+	}
+
+	var peek struct {
+		EventType WebhookEventType `json:"eventType"`
+	}
+	err = json.Unmarshal(envelope.Resource, &peek)
+	if err != nil {
+		return err
+	}
+
+	resource := newWebhookPayload(WebhookTopic(envelope.Topic), peek.EventType)
+	if resource == nil {
+		w.Resource = &WebhookUnknownResource{EventType: peek.EventType, Raw: envelope.Resource}
+		return nil
+	}
+
+	err = json.Unmarshal(envelope.Resource, resource)
+	if err != nil {
+		return err
+	}
+	w.Resource = resource
+
+	return nil
+}
+
+// newWebhookPayload returns the resource type for eventType, falling back to the topic
+// if the resource carries no event type. Both are matched case-insensitively. It returns nil
+// for unknown resources.
+func newWebhookPayload(topic WebhookTopic, eventType WebhookEventType) WebhookPayload {
+	switch {
+	case matchEventType(eventType, OrderShipped, OrderInvoiced, OrderHold, OrderVoided):
+		return &WebhookResource{}
+	case matchEventType(eventType, StockUpdate):
+		return &WebhookStockResource{}
+	case matchEventType(eventType, PriceUpdate):
+		return &WebhookPriceResource{}
+	case eventType == "":
+		switch WebhookTopic(strings.ToLower(string(topic))) {
+		case OrdersTopic:
+			return &WebhookResource{}
+		case StockTopic:
+			return &WebhookStockResource{}
+		case PriceTopic:
+			return &WebhookPriceResource{}
+		}
+	}
+
+	return nil
+}
+
+// matchEventType reports whether eventType is one of types, ignoring case.
+func matchEventType(eventType WebhookEventType, types ...WebhookEventType) bool {
+	for _, t := range types {
+		if strings.EqualFold(string(eventType), string(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	envelope := webhookEnvelope{
+		Topic:          w.Topic,
+		Event:          w.Event,
+		EventTimeStamp: w.EventTimeStamp,
+		EventID:        w.EventID,
+		Resource:       w.RawResource,
+	}
+	if w.Resource != nil {
+		b, err := json.Marshal(w.Resource)
+		if err != nil {
+			return nil, err
+		}
+		envelope.Resource = b
+	}
+
+	return marshalExtra(&envelope, w.Extra)
+}
+
+// OrderResource returns the resource of order events.
+func (w *Webhook) OrderResource() (*WebhookResource, bool) {
+	r, ok := w.Resource.(*WebhookResource)
+	return r, ok
+}
+
+// StockResource returns the resource of stock update events.
+func (w *Webhook) StockResource() (*WebhookStockResource, bool) {
+	r, ok := w.Resource.(*WebhookStockResource)
+	return r, ok
+}
+
+// PriceResource returns the resource of price update events.
+func (w *Webhook) PriceResource() (*WebhookPriceResource, bool) {
+	r, ok := w.Resource.(*WebhookPriceResource)
+	return r, ok
+}
+
+// WebhookUnknownResource holds resources of topics and event types this package does not know about.
+type WebhookUnknownResource struct {
+	EventType WebhookEventType
+	Raw       json.RawMessage
+}
+
+func (r *WebhookUnknownResource) Type() WebhookEventType { return r.EventType }
+
+func (r *WebhookUnknownResource) MarshalJSON() ([]byte, error) {
+	if len(r.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return r.Raw, nil
 }
 
 type WebhookResource struct {
 	EventType           WebhookEventType      `json:"eventType"`
 	OrderNumber         string                `json:"orderNumber"`
 	CustomerPoNumber    string                `json:"customerPoNumber"`
-	OrderEntryTimeStamp time.Time             `json:"orderEntryTimeStamp"`
+	OrderEntryTimeStamp Timestamp             `json:"orderEntryTimeStamp"`
 	Lines               []WebhookResourceLine `json:"lines"`
+
+	// Extra holds the fields sent by Ingram without a struct field.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+func (r *WebhookResource) UnmarshalJSON(data []byte) error {
+	type plain WebhookResource
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookResource) MarshalJSON() ([]byte, error) {
+	type plain WebhookResource
+	return marshalExtra((*plain)(&r), r.Extra)
+}
+
+func (r *WebhookResource) Type() WebhookEventType { return r.EventType }
+
 type WebhookResourceLine struct {
 	LineNumber          string                      `json:"lineNumber"`
 	SubOrderNumber      string                      `json:"subOrderNumber"`
 	LineStatus          string                      `json:"lineStatus"`
 	IngramPartNumber    string                      `json:"ingramPartNumber"`
 	VendorPartNumber    string                      `json:"vendorPartNumber"`
-	RequestedQuantity   float64                     `json:"requestedQuantity"`
-	ShippedQuantity     float64                     `json:"shippedQuantity"`
-	BackOrderedQuantity float64                     `json:"backOrderedQuantity"`
+	RequestedQuantity   FlexInt                     `json:"requestedQuantity"`
+	ShippedQuantity     FlexInt                     `json:"shippedQuantity"`
+	BackOrderedQuantity FlexInt                     `json:"backOrderedQuantity"`
 	ShipmentDetails     WebhookShipmentDetail       `json:"shipmentDetails"`
 	SerialNumberDetails []WebhookSerialNumberDetail `json:"serialNumberDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookResourceLine) UnmarshalJSON(data []byte) error {
+	type plain WebhookResourceLine
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookResourceLine) MarshalJSON() ([]byte, error) {
+	type plain WebhookResourceLine
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type WebhookShipmentDetail struct {
-	ShipmentDate        *string                        `json:"shipmentDate"`
+	ShipmentDate        Timestamp                      `json:"shipmentDate"`
 	ShipFromWarehouseID string                         `json:"shipFromWarehouseId"`
 	WarehouseName       string                         `json:"warehouseName"`
 	CarrierCode         string                         `json:"carrierCode"`
 	CarrierName         string                         `json:"carrierName"`
 	PackageDetails      []WebhookShipmentPackageDetail `json:"packageDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookShipmentDetail) UnmarshalJSON(data []byte) error {
+	type plain WebhookShipmentDetail
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookShipmentDetail) MarshalJSON() ([]byte, error) {
+	type plain WebhookShipmentDetail
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type WebhookShipmentPackageDetail struct {
 	CartonNumber   string  `json:"cartonNumber"`
-	QuantityInbox  float64 `json:"quantityInbox"`
+	QuantityInbox  FlexInt `json:"quantityInbox"`
 	TrackingNumber string  `json:"trackingNumber"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookShipmentPackageDetail) UnmarshalJSON(data []byte) error {
+	type plain WebhookShipmentPackageDetail
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookShipmentPackageDetail) MarshalJSON() ([]byte, error) {
+	type plain WebhookShipmentPackageDetail
+	return marshalExtra((*plain)(&r), r.Extra)
 }
 
 type WebhookSerialNumberDetail struct {
 	SerialNumber string `json:"serialNumber"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookSerialNumberDetail) UnmarshalJSON(data []byte) error {
+	type plain WebhookSerialNumberDetail
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
 }
+
+func (r WebhookSerialNumberDetail) MarshalJSON() ([]byte, error) {
+	type plain WebhookSerialNumberDetail
+	return marshalExtra((*plain)(&r), r.Extra)
+}
+
+type WebhookStockResource struct {
+	EventType               WebhookEventType          `json:"eventType"`
+	IngramPartNumber        string                    `json:"ingramPartNumber"`
+	VendorPartNumber        string                    `json:"vendorPartNumber"`
+	UPC                     string                    `json:"upc"`
+	Available               bool                      `json:"available"`
+	TotalAvailability       int64                     `json:"totalAvailability"`
+	AvailabilityByWarehouse []AvailabilityByWarehouse `json:"availabilityByWarehouse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookStockResource) UnmarshalJSON(data []byte) error {
+	type plain WebhookStockResource
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookStockResource) MarshalJSON() ([]byte, error) {
+	type plain WebhookStockResource
+	return marshalExtra((*plain)(&r), r.Extra)
+}
+
+func (r *WebhookStockResource) Type() WebhookEventType { return r.EventType }
+
+type WebhookPriceResource struct {
+	EventType        WebhookEventType `json:"eventType"`
+	IngramPartNumber string           `json:"ingramPartNumber"`
+	VendorPartNumber string           `json:"vendorPartNumber"`
+	UPC              string           `json:"upc"`
+	CurrencyCode     string           `json:"currencyCode"`
+	RetailPrice      Decimal          `json:"retailPrice"`
+	MapPrice         Decimal          `json:"mapPrice"`
+	CustomerPrice    Decimal          `json:"customerPrice"`
+	EffectiveDate    Timestamp        `json:"effectiveDate"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookPriceResource) UnmarshalJSON(data []byte) error {
+	type plain WebhookPriceResource
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookPriceResource) MarshalJSON() ([]byte, error) {
+	type plain WebhookPriceResource
+	return marshalExtra((*plain)(&r), r.Extra)
+}
+
+func (r *WebhookPriceResource) Retail() Money   { return r.RetailPrice.In(r.CurrencyCode) }
+func (r *WebhookPriceResource) MAP() Money      { return r.MapPrice.In(r.CurrencyCode) }
+func (r *WebhookPriceResource) Customer() Money { return r.CustomerPrice.In(r.CurrencyCode) }
+
+func (r *WebhookPriceResource) Type() WebhookEventType { return r.EventType }
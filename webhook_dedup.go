@@ -0,0 +1,384 @@
+package ingram
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEventStoreSize = 10000
+	defaultEventStoreTTL  = 72 * time.Hour
+
+	// maxWebhookSize limits the body of webhook deliveries accepted by NewWebhookHandler.
+	maxWebhookSize = 1 << 20
+)
+
+// WebhookEventStore keeps track of the webhook event IDs that were already processed.
+type WebhookEventStore interface {
+	// Add records eventID and reports whether it has been seen before.
+	Add(ctx context.Context, eventID string) (bool, error)
+	// Remove forgets eventID, so a redelivery of the event gets processed again.
+	Remove(ctx context.Context, eventID string) error
+}
+
+// WebhookHandlerFunc processes a single webhook delivery.
+type WebhookHandlerFunc func(ctx context.Context, webhook *Webhook) error
+
+// DeduplicateWebhooks wraps handler so every EventID is dispatched only once.
+// Redeliveries of an already processed event are acknowledged without calling handler.
+// If handler fails, the event is removed from the store again so Ingram's retry gets processed.
+func DeduplicateWebhooks(store WebhookEventStore, handler WebhookHandlerFunc) WebhookHandlerFunc {
+	return func(ctx context.Context, webhook *Webhook) error {
+		if webhook.EventID == "" {
+			return handler(ctx, webhook)
+		}
+
+		seen, err := store.Add(ctx, webhook.EventID)
+		if err != nil {
+			return err
+		}
+		if seen {
+			return nil
+		}
+
+		err = handler(ctx, webhook)
+		if err != nil {
+			if rErr := store.Remove(ctx, webhook.EventID); rErr != nil {
+				return fmt.Errorf("%w (unable to release event %s: %v)", err, webhook.EventID, rErr)
+			}
+			return err
+		}
+
+		return nil
+	}
+}
+
+// NewWebhookHandler returns a http.Handler decoding Ingram webhook deliveries and passing them to handler.
+// Failing handlers result in a 500 response, which makes Ingram redeliver the event. Bodies larger
+// than 1 MiB are rejected with 413.
+func NewWebhookHandler(handler WebhookHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var webhook Webhook
+		err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookSize)).Decode(&webhook)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = handler(r.Context(), &webhook)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// MemoryEventStore is an in-memory WebhookEventStore evicting the least recently seen
+// events once its size is exceeded and events not seen within its TTL.
+type MemoryEventStore struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type memoryEvent struct {
+	id     string
+	seenAt time.Time
+}
+
+// NewMemoryEventStore creates a MemoryEventStore. Non-positive size and ttl fall back to the defaults.
+func NewMemoryEventStore(size int, ttl time.Duration) *MemoryEventStore {
+	if size <= 0 {
+		size = defaultEventStoreSize
+	}
+	if ttl <= 0 {
+		ttl = defaultEventStoreTTL
+	}
+
+	return &MemoryEventStore{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (s *MemoryEventStore) Add(_ context.Context, eventID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.evict(now)
+
+	if e, ok := s.entries[eventID]; ok {
+		e.Value.(*memoryEvent).seenAt = now
+		s.order.MoveToBack(e)
+		return true, nil
+	}
+
+	s.entries[eventID] = s.order.PushBack(&memoryEvent{id: eventID, seenAt: now})
+	for s.order.Len() > s.size {
+		s.remove(s.order.Front())
+	}
+
+	return false, nil
+}
+
+func (s *MemoryEventStore) Remove(_ context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[eventID]; ok {
+		s.remove(e)
+	}
+
+	return nil
+}
+
+func (s *MemoryEventStore) evict(now time.Time) {
+	for e := s.order.Front(); e != nil; e = s.order.Front() {
+		if now.Sub(e.Value.(*memoryEvent).seenAt) < s.ttl {
+			return
+		}
+		s.remove(e)
+	}
+}
+
+func (s *MemoryEventStore) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.entries, e.Value.(*memoryEvent).id)
+}
+
+// ErrInvalidEventID is returned by FileEventStore for event IDs containing line breaks or tabs.
+var ErrInvalidEventID = errors.New("invalid event id")
+
+// tombstone replaces the timestamp of removed events in the event file.
+const tombstone = "-"
+
+// minCompactLines is the number of lines below which FileEventStore never compacts its file.
+const minCompactLines = 1000
+
+// FileEventStore is a WebhookEventStore persisting event IDs in a local file, so
+// deduplication survives restarts. Removed events are appended as tombstones. Expired events are
+// evicted on Add, the file is compacted once it holds more than twice as many lines as unexpired events.
+type FileEventStore struct {
+	path string
+	ttl  time.Duration
+
+	mu     sync.Mutex
+	file   *os.File
+	lines  int
+	events map[string]time.Time
+	order  *list.List
+}
+
+// NewFileEventStore opens or creates the event file at path. A non-positive ttl falls back to the default.
+func NewFileEventStore(path string, ttl time.Duration) (*FileEventStore, error) {
+	if ttl <= 0 {
+		ttl = defaultEventStoreTTL
+	}
+
+	s := &FileEventStore{
+		path:   path,
+		ttl:    ttl,
+		events: make(map[string]time.Time),
+		order:  list.New(),
+	}
+
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.rewrite()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileEventStore) Add(_ context.Context, eventID string) (bool, error) {
+	if strings.ContainsAny(eventID, "\t\r\n") {
+		return false, fmt.Errorf("%w %q", ErrInvalidEventID, eventID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.evict(now)
+
+	if _, ok := s.events[eventID]; ok {
+		return true, nil
+	}
+
+	if s.lines >= minCompactLines && s.lines > 2*len(s.events) {
+		err := s.rewrite()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	_, err := fmt.Fprintf(s.file, "%d\t%s\n", now.Unix(), eventID)
+	if err != nil {
+		return false, err
+	}
+	s.lines++
+	s.events[eventID] = now
+	s.order.PushBack(&memoryEvent{id: eventID, seenAt: now})
+
+	return false, nil
+}
+
+func (s *FileEventStore) Remove(_ context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.events[eventID]; !ok {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(s.file, "%s\t%s\n", tombstone, eventID)
+	if err != nil {
+		return err
+	}
+	s.lines++
+	delete(s.events, eventID)
+
+	return nil
+}
+
+// Close closes the underlying file.
+func (s *FileEventStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// evict forgets the events older than the TTL. order may still hold events that were removed
+// or added again since, those are skipped.
+func (s *FileEventStore) evict(now time.Time) {
+	for e := s.order.Front(); e != nil; e = s.order.Front() {
+		event := e.Value.(*memoryEvent)
+		if now.Sub(event.seenAt) < s.ttl {
+			return
+		}
+		s.order.Remove(e)
+		if seenAt, ok := s.events[event.id]; ok && seenAt.Equal(event.seenAt) {
+			delete(s.events, event.id)
+		}
+	}
+}
+
+func (s *FileEventStore) load() error {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	now := time.Now()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ts, id, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok || id == "" {
+			continue
+		}
+		if ts == tombstone {
+			delete(s.events, id)
+			continue
+		}
+		unix, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		seenAt := time.Unix(unix, 0)
+		if now.Sub(seenAt) < s.ttl {
+			s.events[id] = seenAt
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	events := make([]*memoryEvent, 0, len(s.events))
+	for id, seenAt := range s.events {
+		events = append(events, &memoryEvent{id: id, seenAt: seenAt})
+	}
+	sort.Slice(events, func(a, b int) bool {
+		return events[a].seenAt.Before(events[b].seenAt)
+	})
+	for _, event := range events {
+		s.order.PushBack(event)
+	}
+
+	return nil
+}
+
+// rewrite compacts the event file to the events currently held in memory. The current file stays
+// in use until the compacted one has replaced it.
+func (s *FileEventStore) rewrite() error {
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for id, seenAt := range s.events {
+		_, err = fmt.Fprintf(w, "%d\t%s\n", seenAt.Unix(), id)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.path)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file = f
+	s.lines = len(s.events)
+
+	return nil
+}
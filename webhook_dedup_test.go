@@ -0,0 +1,272 @@
+package ingram
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileEventStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events")
+
+	s, err := NewFileEventStore(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen, err := s.Add(ctx, "a")
+	if err != nil || seen {
+		t.Fatalf("first Add = %v, %v", seen, err)
+	}
+	seen, err = s.Add(ctx, "a")
+	if err != nil || !seen {
+		t.Fatalf("second Add = %v, %v", seen, err)
+	}
+	err = s.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = NewFileEventStore(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen, err = s.Add(ctx, "a")
+	if err != nil || !seen {
+		t.Fatalf("Add after reopening = %v, %v", seen, err)
+	}
+	err = s.Remove(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = NewFileEventStore(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	seen, err = s.Add(ctx, "a")
+	if err != nil || seen {
+		t.Fatalf("Add after Remove = %v, %v", seen, err)
+	}
+}
+
+func TestFileEventStoreFailedRemove(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileEventStore(filepath.Join(t.TempDir(), "events"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Add(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Remove(ctx, "a")
+	if err == nil {
+		t.Fatal("Remove on closed file succeeded")
+	}
+	if _, ok := s.events["a"]; !ok {
+		t.Error("failed Remove forgot the event")
+	}
+}
+
+func TestFileEventStoreInvalidID(t *testing.T) {
+	s, err := NewFileEventStore(filepath.Join(t.TempDir(), "events"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	for _, id := range []string{"a\tb", "a\nb", "a\r"} {
+		_, err = s.Add(context.Background(), id)
+		if !errors.Is(err, ErrInvalidEventID) {
+			t.Errorf("Add(%q) = %v, want ErrInvalidEventID", id, err)
+		}
+	}
+}
+
+func TestFileEventStoreEviction(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events")
+	s, err := NewFileEventStore(path, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	for n := 0; n < minCompactLines; n++ {
+		_, err = s.Add(ctx, strconv.Itoa(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	seen, err := s.Add(ctx, "0")
+	if err != nil || seen {
+		t.Fatalf("Add of expired event = %v, %v", seen, err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(b), "\n"); lines != 1 {
+		t.Errorf("file holds %d lines after compaction, want 1", lines)
+	}
+}
+
+func TestFileEventStoreFailedRewrite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events")
+	s, err := NewFileEventStore(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	_, err = s.Add(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A non-empty directory in place of the event file makes the rename fail.
+	err = os.Remove(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.MkdirAll(filepath.Join(path, "dir"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.rewrite()
+	if err == nil {
+		t.Fatal("rewrite succeeded, want rename error")
+	}
+	seen, err := s.Add(ctx, "a")
+	if err != nil || !seen {
+		t.Errorf("Add after failed rewrite = %v, %v", seen, err)
+	}
+	_, err = s.Add(ctx, "b")
+	if err != nil {
+		t.Errorf("Add after failed rewrite: %v", err)
+	}
+}
+
+func TestWebhookHandlerBodyLimit(t *testing.T) {
+	called := false
+	h := NewWebhookHandler(func(context.Context, *Webhook) error {
+		called = true
+		return nil
+	})
+
+	body := `{"eventId":"` + strings.Repeat("a", maxWebhookSize) + `"}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	if called {
+		t.Error("handler called for oversized body")
+	}
+}
+
+func TestMemoryEventStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryEventStore(2, 0)
+
+	for _, id := range []string{"a", "b"} {
+		seen, err := s.Add(ctx, id)
+		if err != nil || seen {
+			t.Fatalf("Add(%s) = %v, %v", id, seen, err)
+		}
+	}
+	// Seeing a again makes b the least recently seen event, so c evicts b.
+	seen, _ := s.Add(ctx, "a")
+	if !seen {
+		t.Fatal("a not seen")
+	}
+	_, _ = s.Add(ctx, "c")
+	for id, want := range map[string]bool{"a": true, "c": true, "b": false} {
+		_, ok := s.entries[id]
+		if ok != want {
+			t.Errorf("%s held = %v, want %v", id, ok, want)
+		}
+	}
+
+	err := s.Remove(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen, _ = s.Add(ctx, "a")
+	if seen {
+		t.Error("a seen after Remove")
+	}
+}
+
+func TestMemoryEventStoreTTL(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryEventStore(0, 10*time.Millisecond)
+
+	_, _ = s.Add(ctx, "a")
+	time.Sleep(20 * time.Millisecond)
+
+	seen, err := s.Add(ctx, "a")
+	if err != nil || seen {
+		t.Errorf("Add of expired event = %v, %v", seen, err)
+	}
+}
+
+func TestDeduplicateWebhooks(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	fail := errors.New("handler failed")
+	var result error
+	handler := DeduplicateWebhooks(NewMemoryEventStore(0, 0), func(context.Context, *Webhook) error {
+		calls++
+		return result
+	})
+
+	webhook := &Webhook{EventID: "event-1"}
+	for n := 0; n < 2; n++ {
+		err := handler(ctx, webhook)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times for a duplicate, want 1", calls)
+	}
+
+	result = fail
+	webhook = &Webhook{EventID: "event-2"}
+	err := handler(ctx, webhook)
+	if !errors.Is(err, fail) {
+		t.Fatalf("error = %v, want handler error", err)
+	}
+	result = nil
+	err = handler(ctx, webhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("handler called %d times, want the failed event redelivered", calls)
+	}
+}
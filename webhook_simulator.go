@@ -0,0 +1,250 @@
+package ingram
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/google/uuid"
+)
+
+// WebhookSignatureHeader is the header Ingram delivers the webhook signature in.
+const WebhookSignatureHeader = "X-Hub-Signature"
+
+// SignWebhook returns the signature Ingram sends for an event: the base64 encoded HMAC-SHA512 of the event ID.
+func SignWebhook(secret, eventID string) string {
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write([]byte(eventID))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature matches the event ID signed with secret.
+func VerifyWebhookSignature(secret, eventID, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, eventID)), []byte(signature))
+}
+
+// webhookDeliveryClient sends simulated and replayed webhooks.
+var webhookDeliveryClient = &http.Client{Timeout: 30 * time.Second}
+
+// VerifyWebhooks rejects deliveries to next that are not signed with secret. Bodies larger than 1 MiB
+// are rejected with 413 before the signature is checked.
+func VerifyWebhooks(secret string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var envelope webhookEnvelope
+		err = json.Unmarshal(body, &envelope)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !VerifyWebhookSignature(secret, envelope.EventID, r.Header.Get(WebhookSignatureHeader)) {
+			http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SimulateWebhook generates a realistic webhook for eventType. Order events get one line per
+// line status; without line statuses, a status matching the event type is used.
+func SimulateWebhook(eventType WebhookEventType, lineStatuses ...WebhookLineStatus) (*Webhook, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	webhook := &Webhook{
+		Event:          string(UpdateEvent),
+		EventTimeStamp: Timestamp{Time: now},
+		EventID:        uuid.NewString(),
+	}
+
+	switch eventType {
+	case OrderShipped, OrderInvoiced, OrderHold, OrderVoided:
+		webhook.Topic = string(OrdersTopic)
+		webhook.Resource = simulateOrderResource(eventType, now, lineStatuses)
+	case StockUpdate:
+		webhook.Topic = string(StockTopic)
+		webhook.Resource = simulateStockResource()
+	case PriceUpdate:
+		webhook.Topic = string(PriceTopic)
+		webhook.Resource = simulatePriceResource(now)
+	default:
+		return nil, fmt.Errorf("unsupported webhook event type %q", eventType)
+	}
+
+	return webhook, nil
+}
+
+func simulateOrderResource(eventType WebhookEventType, now time.Time, lineStatuses []WebhookLineStatus) *WebhookResource {
+	if len(lineStatuses) == 0 {
+		switch eventType {
+		case OrderShipped, OrderInvoiced:
+			lineStatuses = []WebhookLineStatus{LineShipped}
+		case OrderHold:
+			lineStatuses = []WebhookLineStatus{LineSalesHold}
+		default:
+			lineStatuses = []WebhookLineStatus{""}
+		}
+	}
+
+	orderNumber := fmt.Sprintf("40-%05d", rand.Intn(100000))
+	resource := &WebhookResource{
+		EventType:           eventType,
+		OrderNumber:         orderNumber,
+		CustomerPoNumber:    fmt.Sprintf("PO-%06d", rand.Intn(1000000)),
+		OrderEntryTimeStamp: Timestamp{Time: now.Add(-26 * time.Hour)},
+	}
+
+	for n, status := range lineStatuses {
+		quantity := FlexInt(1 + rand.Intn(10))
+		line := WebhookResourceLine{
+			LineNumber:        fmt.Sprintf("%03d", n+1),
+			SubOrderNumber:    fmt.Sprintf("%s-%02d", orderNumber, 11+n),
+			LineStatus:        string(status),
+			IngramPartNumber:  fmt.Sprintf("%07d", rand.Intn(10000000)),
+			VendorPartNumber:  fmt.Sprintf("VPN-%04d", rand.Intn(10000)),
+			RequestedQuantity: quantity,
+		}
+
+		if status == LineShipped {
+			line.ShippedQuantity = quantity
+			line.ShipmentDetails = WebhookShipmentDetail{
+				ShipmentDate:        Timestamp{Time: now.Truncate(24 * time.Hour)},
+				ShipFromWarehouseID: "80",
+				WarehouseName:       "Straubing",
+				CarrierCode:         "DH",
+				CarrierName:         "DHL",
+				PackageDetails: []WebhookShipmentPackageDetail{{
+					CartonNumber:   "1",
+					QuantityInbox:  quantity,
+					TrackingNumber: fmt.Sprintf("00340434%012d", rand.Int63n(1000000000000)),
+				}},
+			}
+			line.SerialNumberDetails = []WebhookSerialNumberDetail{{
+				SerialNumber: strings.ToUpper(uuid.NewString()[:12]),
+			}}
+		} else {
+			line.BackOrderedQuantity = quantity
+		}
+
+		resource.Lines = append(resource.Lines, line)
+	}
+
+	return resource
+}
+
+func simulateStockResource() *WebhookStockResource {
+	quantity := int64(rand.Intn(500))
+	return &WebhookStockResource{
+		EventType:         StockUpdate,
+		IngramPartNumber:  fmt.Sprintf("%07d", rand.Intn(10000000)),
+		VendorPartNumber:  fmt.Sprintf("VPN-%04d", rand.Intn(10000)),
+		Available:         quantity > 0,
+		TotalAvailability: quantity,
+		AvailabilityByWarehouse: []AvailabilityByWarehouse{{
+			Location:          "Straubing",
+			WarehouseId:       "80",
+			QuantityAvailable: quantity,
+		}},
+	}
+}
+
+func simulatePriceResource(now time.Time) *WebhookPriceResource {
+	price := NewDecimal(int64(1000+rand.Intn(100000)), 2)
+	return &WebhookPriceResource{
+		EventType:        PriceUpdate,
+		IngramPartNumber: fmt.Sprintf("%07d", rand.Intn(10000000)),
+		VendorPartNumber: fmt.Sprintf("VPN-%04d", rand.Intn(10000)),
+		CurrencyCode:     "EUR",
+		RetailPrice:      price.Mul(MustParseDecimal("1.3")).Round(2),
+		MapPrice:         price.Mul(MustParseDecimal("1.2")).Round(2),
+		CustomerPrice:    price,
+		EffectiveDate:    Timestamp{Time: now.Truncate(24 * time.Hour)},
+	}
+}
+
+// DeliverWebhook posts webhook to url, signed with secret if it is not empty. Deliveries time out after 30 seconds.
+func DeliverWebhook(ctx context.Context, url, secret string, webhook *Webhook) error {
+	b, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+
+	return deliverWebhook(ctx, url, secret, webhook.EventID, b)
+}
+
+// ReplayWebhooks posts every *.json file in dir, in lexical order, to url. The payloads are
+// delivered unchanged and signed with secret if it is not empty.
+func ReplayWebhooks(ctx context.Context, dir, url, secret string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var envelope webhookEnvelope
+		err = json.Unmarshal(b, &envelope)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		err = deliverWebhook(ctx, url, secret, envelope.EventID, b)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+func deliverWebhook(ctx context.Context, url, secret, eventID string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, eventID))
+	}
+
+	res, err := webhookDeliveryClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("%s: %s", res.Status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package ingram
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	signature := SignWebhook("secret", "event-1")
+	if !VerifyWebhookSignature("secret", "event-1", signature) {
+		t.Error("signature of event-1 not verified")
+	}
+	if VerifyWebhookSignature("secret", "event-2", signature) {
+		t.Error("signature of event-1 verified for event-2")
+	}
+	if VerifyWebhookSignature("other", "event-1", signature) {
+		t.Error("signature verified with another secret")
+	}
+}
+
+func TestVerifyWebhooks(t *testing.T) {
+	var got []byte
+	h := VerifyWebhooks("secret", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = io.ReadAll(r.Body)
+	}))
+	body := `{"topic":"resellers/orders","eventId":"event-1"}`
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		status    int
+	}{
+		{"valid", body, SignWebhook("secret", "event-1"), http.StatusOK},
+		{"tampered body", strings.Replace(body, "event-1", "event-2", 1), SignWebhook("secret", "event-1"), http.StatusUnauthorized},
+		{"missing header", body, "", http.StatusUnauthorized},
+		{"invalid header", body, "not-a-signature", http.StatusUnauthorized},
+		{"invalid JSON", "{", SignWebhook("secret", "event-1"), http.StatusBadRequest},
+		{"too large", `{"eventId":"` + strings.Repeat("a", maxWebhookSize) + `"}`, "", http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(WebhookSignatureHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status == http.StatusOK && string(got) != tt.body {
+				t.Errorf("next got body %s, want %s", got, tt.body)
+			}
+			if tt.status != http.StatusOK && got != nil {
+				t.Error("next called for rejected delivery")
+			}
+		})
+	}
+}
+
+// webhookReceiver records verified deliveries.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	webhooks []*Webhook
+}
+
+func (rec *webhookReceiver) handler() http.Handler {
+	return VerifyWebhooks("secret", NewWebhookHandler(func(_ context.Context, webhook *Webhook) error {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		rec.webhooks = append(rec.webhooks, webhook)
+		return nil
+	}))
+}
+
+func TestSimulateAndDeliverWebhook(t *testing.T) {
+	rec := &webhookReceiver{}
+	srv := httptest.NewServer(rec.handler())
+	defer srv.Close()
+
+	for _, eventType := range []WebhookEventType{OrderShipped, OrderInvoiced, OrderHold, OrderVoided, StockUpdate, PriceUpdate} {
+		webhook, err := SimulateWebhook(eventType)
+		if err != nil {
+			t.Fatalf("SimulateWebhook(%s): %v", eventType, err)
+		}
+		err = DeliverWebhook(context.Background(), srv.URL, "secret", webhook)
+		if err != nil {
+			t.Fatalf("DeliverWebhook(%s): %v", eventType, err)
+		}
+	}
+	if _, err := SimulateWebhook("IM::unknown"); err == nil {
+		t.Error("SimulateWebhook of an unknown event type succeeded")
+	}
+
+	if len(rec.webhooks) != 6 {
+		t.Fatalf("received %d webhooks, want 6", len(rec.webhooks))
+	}
+	order, ok := rec.webhooks[0].OrderResource()
+	if !ok || order.EventType != OrderShipped || len(order.Lines) != 1 || order.Lines[0].LineStatus != string(LineShipped) {
+		t.Errorf("order shipped resource = %+v", rec.webhooks[0].Resource)
+	}
+	if _, ok := rec.webhooks[4].StockResource(); !ok {
+		t.Errorf("stock update resource = %T", rec.webhooks[4].Resource)
+	}
+	if _, ok := rec.webhooks[5].PriceResource(); !ok {
+		t.Errorf("price update resource = %T", rec.webhooks[5].Resource)
+	}
+
+	webhook, _ := SimulateWebhook(StockUpdate)
+	err := DeliverWebhook(context.Background(), srv.URL, "wrong", webhook)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("delivery with the wrong secret returned %v, want 401", err)
+	}
+}
+
+func TestReplayWebhooks(t *testing.T) {
+	rec := &webhookReceiver{}
+	srv := httptest.NewServer(rec.handler())
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for _, id := range []string{"b", "a"} {
+		webhook, err := SimulateWebhook(PriceUpdate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		webhook.EventID = id
+		b, err := json.Marshal(webhook)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dir, id+".json"), b, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("{"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ReplayWebhooks(context.Background(), dir, srv.URL, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.webhooks) != 2 || rec.webhooks[0].EventID != "a" || rec.webhooks[1].EventID != "b" {
+		t.Errorf("replayed %+v, want a and b in order", rec.webhooks)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "c.json"), []byte("{"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReplayWebhooks(context.Background(), dir, srv.URL, "secret")
+	if err == nil || !strings.Contains(err.Error(), "c.json") {
+		t.Errorf("replaying invalid JSON returned %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package ingram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type WebhookSubscription struct {
+	SubscriptionID   string             `json:"subscriptionId,omitempty"`
+	SubscriptionName string             `json:"subscriptionName,omitempty"`
+	CallbackURL      string             `json:"callbackUrl" validate:"required,url"`
+	SecretKey        string             `json:"secretKey,omitempty"`
+	Topic            WebhookTopic       `json:"topic" validate:"required"`
+	Event            WebhookEvent       `json:"event" validate:"required"`
+	EventTypes       []WebhookEventType `json:"eventTypes,omitempty"`
+	Status           string             `json:"status,omitempty"`
+
+	// Extra holds the fields sent by Ingram without a struct field.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *WebhookSubscription) UnmarshalJSON(data []byte) error {
+	type plain WebhookSubscription
+	var err error
+	r.Extra, err = unmarshalExtra(data, (*plain)(r))
+	return err
+}
+
+func (r WebhookSubscription) MarshalJSON() ([]byte, error) {
+	type plain WebhookSubscription
+	return marshalExtra((*plain)(&r), r.Extra)
+}
+
+type WebhookSubscriptionsRequest struct {
+	CustomerNumber string `validate:"required"`
+	ISOCountryCode string `validate:"required"`
+}
+
+type WebhookSubscriptionRequest struct {
+	CustomerNumber string              `validate:"required"`
+	ISOCountryCode string              `validate:"required"`
+	Subscription   WebhookSubscription `validate:"required"`
+}
+
+// updateWebhookSubscriptionRequest validates a WebhookSubscriptionRequest for updates, which need the subscription ID.
+type updateWebhookSubscriptionRequest struct {
+	*WebhookSubscriptionRequest
+	SubscriptionID string `validate:"required"`
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	CustomerNumber string `validate:"required"`
+	ISOCountryCode string `validate:"required"`
+	SubscriptionID string `validate:"required"`
+}
+
+type webhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+}
+
+// NewOrderWebhookSubscription returns a subscription for all order update events delivered to callbackURL.
+func NewOrderWebhookSubscription(name, callbackURL, secretKey string) WebhookSubscription {
+	return WebhookSubscription{
+		SubscriptionName: name,
+		CallbackURL:      callbackURL,
+		SecretKey:        secretKey,
+		Topic:            OrdersTopic,
+		Event:            UpdateEvent,
+		EventTypes:       []WebhookEventType{OrderShipped, OrderInvoiced, OrderHold, OrderVoided},
+	}
+}
+
+// NewStockWebhookSubscription returns a subscription for stock updates of the subscribed SKUs delivered to callbackURL.
+func NewStockWebhookSubscription(name, callbackURL, secretKey string) WebhookSubscription {
+	return WebhookSubscription{
+		SubscriptionName: name,
+		CallbackURL:      callbackURL,
+		SecretKey:        secretKey,
+		Topic:            StockTopic,
+		Event:            UpdateEvent,
+		EventTypes:       []WebhookEventType{StockUpdate},
+	}
+}
+
+// NewPriceWebhookSubscription returns a subscription for price updates of the subscribed SKUs delivered to callbackURL.
+func NewPriceWebhookSubscription(name, callbackURL, secretKey string) WebhookSubscription {
+	return WebhookSubscription{
+		SubscriptionName: name,
+		CallbackURL:      callbackURL,
+		SecretKey:        secretKey,
+		Topic:            PriceTopic,
+		Event:            UpdateEvent,
+		EventTypes:       []WebhookEventType{PriceUpdate},
+	}
+}
+
+func (i *Ingram) CreateWebhookSubscription(ctx context.Context, subscription *WebhookSubscriptionRequest, opts ...CallOption) (*WebhookSubscription, error) {
+	o := i.newCallOptions(ctx, opts)
+	r := *subscription
+	subscription = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
+	err := i.validate.Struct(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := i.newRequest(ctx, o, http.MethodPost, "/resellers/v6/webhooks/subscriptions", nil, subscription.Subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	var response WebhookSubscription
+	err = i.do(&Call{
+		Operation: "CreateWebhookSubscription",
+		Input:     subscription,
+		Request:   req,
+		options:   o,
+		Output:    &response,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (i *Ingram) ListWebhookSubscriptions(ctx context.Context, subscriptions *WebhookSubscriptionsRequest, opts ...CallOption) ([]WebhookSubscription, error) {
+	o := i.newCallOptions(ctx, opts)
+	r := *subscriptions
+	subscriptions = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
+	err := i.validate.Struct(subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := i.newRequest(ctx, o, http.MethodGet, "/resellers/v6/webhooks/subscriptions", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response webhookSubscriptionsResponse
+	err = i.do(&Call{
+		Operation: "ListWebhookSubscriptions",
+		Input:     subscriptions,
+		Request:   req,
+		options:   o,
+		Output:    &response,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Subscriptions, nil
+}
+
+func (i *Ingram) UpdateWebhookSubscription(ctx context.Context, subscription *WebhookSubscriptionRequest, opts ...CallOption) (*WebhookSubscription, error) {
+	o := i.newCallOptions(ctx, opts)
+	r := *subscription
+	subscription = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
+	err := i.validate.Struct(updateWebhookSubscriptionRequest{
+		WebhookSubscriptionRequest: subscription,
+		SubscriptionID:             subscription.Subscription.SubscriptionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := i.newRequest(ctx, o, http.MethodPut, "/resellers/v6/webhooks/subscriptions/"+url.PathEscape(subscription.Subscription.SubscriptionID), nil, subscription.Subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	var response WebhookSubscription
+	err = i.do(&Call{
+		Operation: "UpdateWebhookSubscription",
+		Input:     subscription,
+		Request:   req,
+		options:   o,
+		Output:    &response,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (i *Ingram) DeleteWebhookSubscription(ctx context.Context, subscription *DeleteWebhookSubscriptionRequest, opts ...CallOption) error {
+	o := i.newCallOptions(ctx, opts)
+	r := *subscription
+	subscription = &r
+	o.customer(&r.CustomerNumber, &r.ISOCountryCode)
+	err := i.validate.Struct(subscription)
+	if err != nil {
+		return err
+	}
+
+	req, err := i.newRequest(ctx, o, http.MethodDelete, "/resellers/v6/webhooks/subscriptions/"+url.PathEscape(subscription.SubscriptionID), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return i.do(&Call{
+		Operation: "DeleteWebhookSubscription",
+		Input:     subscription,
+		Request:   req,
+		options:   o,
+	})
+}
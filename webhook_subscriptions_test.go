@@ -0,0 +1,100 @@
+package ingram_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/enthus-golang/ingram"
+	"github.com/enthus-golang/ingram/ingramtest"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestWebhookSubscriptions(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	var requests []string
+	client := newTestClient(t, srv, ingram.WithMiddleware(ingram.BeforeRequest(func(_ context.Context, call *ingram.Call) error {
+		if call.Operation != "GetOAuthToken" {
+			requests = append(requests, call.Request.Method+" "+call.Request.URL.Path)
+		}
+		return nil
+	})))
+	ctx := context.Background()
+
+	created, err := client.CreateWebhookSubscription(ctx, &ingram.WebhookSubscriptionRequest{
+		Subscription: ingram.NewOrderWebhookSubscription("orders", "https://example.com/webhooks", "secret"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.SubscriptionID != "sub-1" || created.Status != "ACTIVE" || created.Topic != ingram.OrdersTopic || len(created.EventTypes) != 4 {
+		t.Errorf("created subscription = %+v", created)
+	}
+
+	subscription := *created
+	subscription.CallbackURL = "https://example.com/orders"
+	updated, err := client.UpdateWebhookSubscription(ctx, &ingram.WebhookSubscriptionRequest{Subscription: subscription})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.SubscriptionID != "sub-1" || updated.CallbackURL != "https://example.com/orders" {
+		t.Errorf("updated subscription = %+v", updated)
+	}
+
+	subscriptions, err := client.ListWebhookSubscriptions(ctx, &ingram.WebhookSubscriptionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].CallbackURL != "https://example.com/orders" {
+		t.Errorf("subscriptions = %+v", subscriptions)
+	}
+
+	err = client.DeleteWebhookSubscription(ctx, &ingram.DeleteWebhookSubscriptionRequest{SubscriptionID: "sub-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscriptions := srv.WebhookSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("subscriptions after delete = %+v", subscriptions)
+	}
+
+	err = client.DeleteWebhookSubscription(ctx, &ingram.DeleteWebhookSubscriptionRequest{SubscriptionID: "sub-1"})
+	var apiErr *ingram.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("deleting a missing subscription = %v, want a 404 *APIError", err)
+	}
+
+	want := []string{
+		"POST /resellers/v6/webhooks/subscriptions",
+		"PUT /resellers/v6/webhooks/subscriptions/sub-1",
+		"GET /resellers/v6/webhooks/subscriptions",
+		"DELETE /resellers/v6/webhooks/subscriptions/sub-1",
+		"DELETE /resellers/v6/webhooks/subscriptions/sub-1",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	for n := range want {
+		if requests[n] != want[n] {
+			t.Errorf("request %d = %s, want %s", n, requests[n], want[n])
+		}
+	}
+}
+
+func TestUpdateWebhookSubscriptionWithoutID(t *testing.T) {
+	srv := ingramtest.NewServer()
+	defer srv.Close()
+	client := newTestClient(t, srv)
+
+	_, err := client.UpdateWebhookSubscription(context.Background(), &ingram.WebhookSubscriptionRequest{
+		Subscription: ingram.NewStockWebhookSubscription("stock", "https://example.com/webhooks", "secret"),
+	})
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) || validationErrors[0].Field() != "SubscriptionID" {
+		t.Fatalf("error = %v, want a validation error for SubscriptionID", err)
+	}
+	if n := srv.Requests(ingramtest.WebhookSubscriptionsEndpoint); n != 0 {
+		t.Errorf("sent %d requests for an invalid update", n)
+	}
+}
@@ -0,0 +1,75 @@
+package ingram
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestWebhookResourceTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		topic    string
+		resource string
+		want     string
+	}{
+		{name: "order event", topic: "resellers/orders", resource: `{"eventType":"IM::order_shipped"}`, want: "*ingram.WebhookResource"},
+		{name: "event type case", topic: "resellers/orders", resource: `{"eventType":"im::ORDER_SHIPPED"}`, want: "*ingram.WebhookResource"},
+		{name: "stock event", topic: "resellers/stock", resource: `{"eventType":"IM::stock_update"}`, want: "*ingram.WebhookStockResource"},
+		{name: "price event", topic: "resellers/price", resource: `{"eventType":"IM::Price_Update"}`, want: "*ingram.WebhookPriceResource"},
+		{name: "topic without event type", topic: "resellers/price", resource: `{}`, want: "*ingram.WebhookPriceResource"},
+		{name: "topic case", topic: "Resellers/Orders", resource: `{}`, want: "*ingram.WebhookResource"},
+		{name: "event type before topic", topic: "resellers/orders", resource: `{"eventType":"IM::stock_update"}`, want: "*ingram.WebhookStockResource"},
+		{name: "unknown event type", topic: "resellers/orders", resource: `{"eventType":"IM::order_returned","rma":"R1"}`, want: "*ingram.WebhookUnknownResource"},
+		{name: "unknown topic", topic: "resellers/invoices", resource: `{"invoiceNumber":"I1"}`, want: "*ingram.WebhookUnknownResource"},
+		{name: "null resource", topic: "resellers/orders", resource: `null`, want: "<nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var webhook Webhook
+			err := json.Unmarshal([]byte(`{"topic":"`+tt.topic+`","event":"im::updated","eventId":"1","resource":`+tt.resource+`}`), &webhook)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprintf("%T", webhook.Resource); got != tt.want {
+				t.Fatalf("resource is %s, want %s", got, tt.want)
+			}
+
+			unknown, ok := webhook.Resource.(*WebhookUnknownResource)
+			if !ok {
+				return
+			}
+			if string(unknown.Raw) != tt.resource {
+				t.Errorf("raw resource = %s, want %s", unknown.Raw, tt.resource)
+			}
+			b, err := json.Marshal(webhook)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var roundTrip struct {
+				Resource json.RawMessage `json:"resource"`
+			}
+			err = json.Unmarshal(b, &roundTrip)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(roundTrip.Resource) != tt.resource {
+				t.Errorf("marshaled resource = %s, want %s", roundTrip.Resource, tt.resource)
+			}
+		})
+	}
+}
+
+func TestWebhookQuantities(t *testing.T) {
+	var line WebhookResourceLine
+	err := json.Unmarshal([]byte(`{"requestedQuantity":"3","shippedQuantity":2.0,"backOrderedQuantity":1,"shipmentDetails":{"packageDetails":[{"quantityInbox":"2.0"}]}}`), &line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line.RequestedQuantity != 3 || line.ShippedQuantity != 2 || line.BackOrderedQuantity != 1 {
+		t.Errorf("quantities = %d, %d, %d", line.RequestedQuantity, line.ShippedQuantity, line.BackOrderedQuantity)
+	}
+	if got := line.ShipmentDetails.PackageDetails[0].QuantityInbox; got != 2 {
+		t.Errorf("quantity in box = %d, want 2", got)
+	}
+}